@@ -0,0 +1,240 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultFieldManager = "ingress2gateway"
+
+const (
+	dryRunNone   = "none"
+	dryRunClient = "client"
+	dryRunServer = "server"
+)
+
+type ApplyRunner struct {
+	// PrintRunner holds the flags controlling how the resources are read and
+	// converted, so that apply converts exactly what print would print.
+	PrintRunner
+
+	// fieldManager is the name of the manager owning the applied fields. Value
+	// assigned via --field-manager flag.
+	fieldManager string
+
+	// forceConflicts makes the apply take the ownership of the fields managed
+	// by other managers. Value assigned via --force-conflicts flag.
+	forceConflicts bool
+
+	// dryRun is either none, client or server. With client, the objects are
+	// only compared with the live ones. With server, the apply requests are
+	// sent but not persisted. Value assigned via --dry-run flag.
+	dryRun string
+
+	// clusterClient is used to apply the Gateway API objects.
+	clusterClient client.Client
+}
+
+// applyResult is the outcome of applying a single object.
+type applyResult string
+
+const (
+	applyCreated    applyResult = "created"
+	applyConfigured applyResult = "configured"
+	applyUnchanged  applyResult = "unchanged"
+	applyFailed     applyResult = "failed"
+)
+
+type objectApplyResult struct {
+	object client.Object
+	result applyResult
+	err    error
+}
+
+// ApplyGatewayAPIObjects converts the ingresses and provider-specific
+// resources, then server-side applies every Gateway API object and prints the
+// result of each apply. An object failing to apply does not prevent the others
+// from being applied.
+func (ar *ApplyRunner) ApplyGatewayAPIObjects(cmd *cobra.Command, _ []string) error {
+	gatewayResources, err := ar.convert(cmd)
+	if err != nil {
+		return err
+	}
+	// Nothing is applied when the conversion dropped behavior.
+	if err = ar.checkFailOn(); err != nil {
+		return err
+	}
+
+	if ar.clusterClient == nil {
+		ar.clusterClient, err = i2gw.NewClusterClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	objects := gatewayResourcesObjects(gatewayResources)
+	results := make([]objectApplyResult, 0, len(objects))
+	failed := 0
+	for _, obj := range objects {
+		result, err := ar.applyObject(cmd.Context(), obj)
+		if err != nil {
+			failed++
+		}
+		results = append(results, objectApplyResult{object: obj, result: result, err: err})
+	}
+
+	fmt.Println(ar.createApplyResultTable(results))
+
+	if failed > 0 {
+		return fmt.Errorf("failed to apply %d out of %d objects", failed, len(objects))
+	}
+	return nil
+}
+
+func (ar *ApplyRunner) applyObject(ctx context.Context, obj client.Object) (applyResult, error) {
+	desired, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return applyFailed, err
+	}
+	// The status is not part of the applied configuration, and a null
+	// creationTimestamp would make the server claim its ownership.
+	unstructured.RemoveNestedField(desired, "status")
+	unstructured.RemoveNestedField(desired, "metadata", "creationTimestamp")
+
+	var liveContent map[string]interface{}
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+	err = ar.clusterClient.Get(ctx, client.ObjectKeyFromObject(obj), live)
+	switch {
+	case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+	case err != nil:
+		return applyFailed, err
+	default:
+		liveContent = live.UnstructuredContent()
+	}
+
+	if ar.dryRun == dryRunClient {
+		result, _ := diffObject(desired, liveContent)
+		switch result {
+		case objectNew:
+			return applyCreated, nil
+		case objectChanged:
+			return applyConfigured, nil
+		default:
+			return applyUnchanged, nil
+		}
+	}
+
+	applied := &unstructured.Unstructured{Object: desired}
+	opts := []client.PatchOption{client.FieldOwner(ar.fieldManager)}
+	if ar.forceConflicts {
+		opts = append(opts, client.ForceOwnership)
+	}
+	if ar.dryRun == dryRunServer {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err = ar.clusterClient.Patch(ctx, applied, client.Apply, opts...); err != nil {
+		return applyFailed, err
+	}
+
+	if liveContent == nil {
+		return applyCreated, nil
+	}
+	if equality.Semantic.DeepEqual(normalizeObject(liveContent), normalizeObject(applied.UnstructuredContent())) {
+		return applyUnchanged, nil
+	}
+	return applyConfigured, nil
+}
+
+// createApplyResultTable displays the apply results in the same tabular format
+// as the notifications.
+func (ar *ApplyRunner) createApplyResultTable(results []objectApplyResult) string {
+	resultTable := strings.Builder{}
+
+	t := tablewriter.NewWriter(&resultTable)
+	t.SetHeader([]string{"Object", "Result", "Message"})
+	t.SetColWidth(200)
+	t.SetRowLine(true)
+
+	for _, r := range results {
+		message := ""
+		if r.err != nil {
+			message = r.err.Error()
+		}
+		object := r.object.GetObjectKind().GroupVersionKind().Kind + ": " + client.ObjectKeyFromObject(r.object).String()
+		t.Append([]string{object, string(r.result), message})
+	}
+
+	if ar.dryRun == dryRunNone {
+		resultTable.WriteString("Apply results:\n")
+	} else {
+		resultTable.WriteString(fmt.Sprintf("Apply results (%s dry run):\n", ar.dryRun))
+	}
+	t.Render()
+	return resultTable.String()
+}
+
+func (ar *ApplyRunner) validateFlags(cmd *cobra.Command, args []string) error {
+	switch ar.dryRun {
+	case dryRunNone, dryRunClient, dryRunServer:
+	default:
+		return fmt.Errorf("--dry-run must be one of %s, %s or %s, got %q", dryRunNone, dryRunClient, dryRunServer, ar.dryRun)
+	}
+	if ar.fieldManager == "" {
+		return fmt.Errorf("--field-manager must not be empty")
+	}
+	return ar.validateConversionFlags(cmd, args)
+}
+
+func newApplyCommand() *cobra.Command {
+	ar := &ApplyRunner{}
+
+	// applyCmd represents the apply command. It server-side applies the Gateway
+	// API objects generated from Ingress resources to the cluster.
+	var cmd = &cobra.Command{
+		Use:     "apply",
+		Short:   "Server-side applies Gateway API objects generated from ingress and provider-specific resources to the cluster.",
+		RunE:    ar.ApplyGatewayAPIObjects,
+		PreRunE: ar.validateFlags,
+	}
+
+	cmd.Flags().StringVar(&ar.fieldManager, "field-manager", defaultFieldManager,
+		`Name of the manager used to track field ownership.`)
+
+	cmd.Flags().BoolVar(&ar.forceConflicts, "force-conflicts", false,
+		`If true, take the ownership of the fields managed by other managers.`)
+
+	cmd.Flags().StringVar(&ar.dryRun, "dry-run", dryRunNone,
+		fmt.Sprintf(`Must be %q, %q, or %q. If client, only compare the objects with the live ones. If server, submit server-side apply requests without persisting the objects.`,
+			dryRunNone, dryRunServer, dryRunClient))
+
+	ar.addConversionFlags(cmd)
+	return cmd
+}
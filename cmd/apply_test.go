@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func Test_applyObject_clientDryRun(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := gatewayv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to build scheme: %v", err)
+	}
+
+	newGateway := func(name string, className gatewayv1.ObjectName) *gatewayv1.Gateway {
+		gateway := &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: className},
+		}
+		gateway.SetGroupVersionKind(common.GatewayGVK)
+		return gateway
+	}
+
+	ar := ApplyRunner{
+		fieldManager: defaultFieldManager,
+		dryRun:       dryRunClient,
+		clusterClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			newGateway("unchanged", "nginx"),
+			newGateway("configured", "nginx"),
+		).Build(),
+	}
+
+	testCases := []struct {
+		object         client.Object
+		expectedResult applyResult
+	}{
+		{object: newGateway("created", "nginx"), expectedResult: applyCreated},
+		{object: newGateway("configured", "envoy"), expectedResult: applyConfigured},
+		{object: newGateway("unchanged", "nginx"), expectedResult: applyUnchanged},
+	}
+
+	var results []objectApplyResult
+	for _, tc := range testCases {
+		result, err := ar.applyObject(context.Background(), tc.object)
+		if err != nil {
+			t.Fatalf("Expected no error applying %s but got %v", tc.object.GetName(), err)
+		}
+		if result != tc.expectedResult {
+			t.Errorf("Expected %s to be %s, got %s", tc.object.GetName(), tc.expectedResult, result)
+		}
+		results = append(results, objectApplyResult{object: tc.object, result: result})
+	}
+
+	table := ar.createApplyResultTable(results)
+	if !strings.HasPrefix(table, "Apply results (client dry run):") {
+		t.Errorf("Expected table to mention the dry run, got:\n%s", table)
+	}
+	for _, row := range []string{"Gateway: default/created", "Gateway: default/configured", "Gateway: default/unchanged"} {
+		if !strings.Contains(table, row) {
+			t.Errorf("Expected table to contain %q, got:\n%s", row, table)
+		}
+	}
+}
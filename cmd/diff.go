@@ -0,0 +1,239 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// lastAppliedConfigAnnotation is set by kubectl apply on the objects it manages.
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// ignoredMetadataFields are the metadata fields set by the API server, which are
+// never part of the converted objects.
+var ignoredMetadataFields = []string{"managedFields", "resourceVersion", "uid", "generation", "creationTimestamp", "selfLink"}
+
+type DiffRunner struct {
+	// PrintRunner holds the flags controlling how the resources are read and
+	// converted, so that diff converts exactly what print would print.
+	PrintRunner
+
+	// clusterClient is used to fetch the live Gateway API objects.
+	clusterClient client.Client
+}
+
+// objectDiffResult is the outcome of comparing a converted object with the
+// live object of the same kind, namespace and name.
+type objectDiffResult string
+
+const (
+	objectNew       objectDiffResult = "new"
+	objectChanged   objectDiffResult = "changed"
+	objectUnchanged objectDiffResult = "unchanged"
+)
+
+// DiffGatewayAPIObjects converts the ingresses and provider-specific resources,
+// fetches the Gateway API objects with the same names from the cluster, and
+// prints a semantic diff for every object.
+func (dr *DiffRunner) DiffGatewayAPIObjects(cmd *cobra.Command, _ []string) error {
+	gatewayResources, err := dr.convert(cmd)
+	if err != nil {
+		return err
+	}
+
+	if dr.clusterClient == nil {
+		dr.clusterClient, err = i2gw.NewClusterClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = dr.diffObjects(cmd, gatewayResourcesObjects(gatewayResources), os.Stdout); err != nil {
+		return err
+	}
+	return dr.checkFailOn()
+}
+
+func (dr *DiffRunner) diffObjects(cmd *cobra.Command, objects []client.Object, out io.Writer) error {
+	counts := map[objectDiffResult]int{}
+	for _, obj := range objects {
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+
+		desired, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return fmt.Errorf("failed to convert %s %s: %w", kind, client.ObjectKeyFromObject(obj), err)
+		}
+
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+		err = dr.clusterClient.Get(cmd.Context(), client.ObjectKeyFromObject(obj), live)
+		switch {
+		case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+			live = nil
+		case err != nil:
+			return fmt.Errorf("failed to get %s %s: %w", kind, client.ObjectKeyFromObject(obj), err)
+		}
+
+		var liveContent map[string]interface{}
+		if live != nil {
+			liveContent = live.UnstructuredContent()
+		}
+		result, diff := diffObject(desired, liveContent)
+		counts[result]++
+
+		fmt.Fprintf(out, "%s %s: %s\n", kind, client.ObjectKeyFromObject(obj), result)
+		if diff != "" {
+			fmt.Fprintln(out, diff)
+		}
+	}
+
+	fmt.Fprintf(out, "%d new, %d changed, %d unchanged\n", counts[objectNew], counts[objectChanged], counts[objectUnchanged])
+	return nil
+}
+
+// diffObject compares the desired object with the live one, and returns the
+// diff between them, where "-" lines come from the live object and "+" lines
+// from the desired one. Status, server-managed metadata and the fields
+// defaulted by the API server, i.e. set in the live object to their default
+// value but not set in the desired one, are ignored.
+func diffObject(desired, live map[string]interface{}) (objectDiffResult, string) {
+	if live == nil {
+		return objectNew, ""
+	}
+
+	desired = normalizeObject(desired)
+	live = pruneDefaultedFields(normalizeObject(live), desired).(map[string]interface{})
+
+	diff := cmp.Diff(live, desired)
+	if diff == "" {
+		return objectUnchanged, ""
+	}
+	return objectChanged, diff
+}
+
+// normalizeObject returns a copy of the object without status and without the
+// metadata fields managed by the API server.
+func normalizeObject(obj map[string]interface{}) map[string]interface{} {
+	obj = runtime.DeepCopyJSON(obj)
+
+	unstructured.RemoveNestedField(obj, "status")
+	for _, f := range ignoredMetadataFields {
+		unstructured.RemoveNestedField(obj, "metadata", f)
+	}
+	unstructured.RemoveNestedField(obj, "metadata", "annotations", lastAppliedConfigAnnotation)
+	for _, f := range []string{"annotations", "labels"} {
+		if m, found, _ := unstructured.NestedMap(obj, "metadata", f); found && len(m) == 0 {
+			unstructured.RemoveNestedField(obj, "metadata", f)
+		}
+	}
+	return obj
+}
+
+// defaultedFields are the values the API server defaults the fields of the
+// Gateway API objects to, by field path where "*" stands for any list item.
+var defaultedFields = map[string]interface{}{
+	"spec.parentRefs.*.group":                                         "gateway.networking.k8s.io",
+	"spec.parentRefs.*.kind":                                          "Gateway",
+	"spec.listeners.*.allowedRoutes":                                  map[string]interface{}{"namespaces": map[string]interface{}{"from": "Same"}},
+	"spec.listeners.*.tls.mode":                                       "Terminate",
+	"spec.listeners.*.tls.certificateRefs.*.group":                    "",
+	"spec.listeners.*.tls.certificateRefs.*.kind":                     "Secret",
+	"spec.rules.*.backendRefs.*.group":                                "",
+	"spec.rules.*.backendRefs.*.kind":                                 "Service",
+	"spec.rules.*.backendRefs.*.weight":                               int64(1),
+	"spec.rules.*.matches":                                            []interface{}{map[string]interface{}{"path": map[string]interface{}{"type": "PathPrefix", "value": "/"}}},
+	"spec.rules.*.matches.*.path":                                     map[string]interface{}{"type": "PathPrefix", "value": "/"},
+	"spec.rules.*.matches.*.headers.*.type":                           "Exact",
+	"spec.rules.*.matches.*.queryParams.*.type":                       "Exact",
+	"spec.rules.*.filters.*.requestRedirect.statusCode":               int64(302),
+	"spec.rules.*.filters.*.requestMirror.backendRef.group":           "",
+	"spec.rules.*.filters.*.requestMirror.backendRef.kind":            "Service",
+	"spec.rules.*.backendRefs.*.filters.*.requestRedirect.statusCode": int64(302),
+}
+
+// pruneDefaultedFields removes from the live value the fields that are not set
+// in the desired value and hold the value the API server defaults them to, see
+// defaultedFields. Any other field missing from the desired value is kept, so
+// that it shows in the diff. List items are pruned against the desired item at
+// the same index.
+func pruneDefaultedFields(live, desired interface{}, path ...string) interface{} {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		liveValue, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		pruned := map[string]interface{}{}
+		for k, v := range liveValue {
+			fieldPath := append(path[:len(path):len(path)], k)
+			if d, ok := desiredValue[k]; ok {
+				pruned[k] = pruneDefaultedFields(v, d, fieldPath...)
+				continue
+			}
+			if defaultValue, ok := defaultedFields[strings.Join(fieldPath, ".")]; ok && reflect.DeepEqual(v, defaultValue) {
+				continue
+			}
+			pruned[k] = v
+		}
+		return pruned
+	case []interface{}:
+		liveValue, ok := live.([]interface{})
+		if !ok {
+			return live
+		}
+		itemPath := append(path[:len(path):len(path)], "*")
+		pruned := make([]interface{}, 0, len(liveValue))
+		for i, v := range liveValue {
+			if i < len(desiredValue) {
+				v = pruneDefaultedFields(v, desiredValue[i], itemPath...)
+			}
+			pruned = append(pruned, v)
+		}
+		return pruned
+	default:
+		return live
+	}
+}
+
+func newDiffCommand() *cobra.Command {
+	dr := &DiffRunner{}
+
+	// diffCmd represents the diff command. It compares the Gateway API objects
+	// generated from Ingress resources with the live ones in the cluster.
+	var cmd = &cobra.Command{
+		Use:     "diff",
+		Short:   "Compares Gateway API objects generated from ingress and provider-specific resources with the ones in the cluster.",
+		RunE:    dr.DiffGatewayAPIObjects,
+		PreRunE: dr.validateConversionFlags,
+	}
+
+	dr.addConversionFlags(cmd)
+	return cmd
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_diffObject(t *testing.T) {
+	desired := map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "HTTPRoute",
+		"metadata": map[string]interface{}{
+			"name":              "example-com",
+			"namespace":         "default",
+			"creationTimestamp": nil,
+		},
+		"spec": map[string]interface{}{
+			"hostnames": []interface{}{"example.com"},
+			"rules": []interface{}{
+				map[string]interface{}{
+					"backendRefs": []interface{}{
+						map[string]interface{}{"name": "example", "port": int64(80)},
+					},
+				},
+			},
+		},
+		"status": map[string]interface{}{"parents": nil},
+	}
+
+	live := func(port int64) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "gateway.networking.k8s.io/v1",
+			"kind":       "HTTPRoute",
+			"metadata": map[string]interface{}{
+				"name":              "example-com",
+				"namespace":         "default",
+				"creationTimestamp": "2024-01-01T00:00:00Z",
+				"generation":        int64(1),
+				"resourceVersion":   "1234",
+				"uid":               "0b7e8c1f-6d8e-4f6a-9c3b-3f5e1a2b4c5d",
+				"managedFields":     []interface{}{map[string]interface{}{"manager": "kubectl"}},
+				"annotations": map[string]interface{}{
+					lastAppliedConfigAnnotation: "{}",
+				},
+			},
+			"spec": map[string]interface{}{
+				"hostnames": []interface{}{"example.com"},
+				"rules": []interface{}{
+					map[string]interface{}{
+						"backendRefs": []interface{}{
+							map[string]interface{}{"group": "", "kind": "Service", "name": "example", "port": port, "weight": int64(1)},
+						},
+						"matches": []interface{}{
+							map[string]interface{}{"path": map[string]interface{}{"type": "PathPrefix", "value": "/"}},
+						},
+					},
+				},
+			},
+			"status": map[string]interface{}{
+				"parents": []interface{}{map[string]interface{}{"controllerName": "example.com/gateway-controller"}},
+			},
+		}
+	}
+
+	liveWithFilter := live(80)
+	liveRules, _, _ := unstructured.NestedSlice(liveWithFilter, "spec", "rules")
+	liveRules[0].(map[string]interface{})["filters"] = []interface{}{
+		map[string]interface{}{"type": "RequestRedirect", "requestRedirect": map[string]interface{}{"scheme": "https", "statusCode": int64(301)}},
+	}
+	_ = unstructured.SetNestedSlice(liveWithFilter, liveRules, "spec", "rules")
+
+	liveWithWeight := live(80)
+	liveRules, _, _ = unstructured.NestedSlice(liveWithWeight, "spec", "rules")
+	liveRules[0].(map[string]interface{})["backendRefs"].([]interface{})[0].(map[string]interface{})["weight"] = int64(5)
+	_ = unstructured.SetNestedSlice(liveWithWeight, liveRules, "spec", "rules")
+
+	liveWithHostname := live(80)
+	_ = unstructured.SetNestedStringSlice(liveWithHostname, []string{"example.com", "www.example.com"}, "spec", "hostnames")
+
+	testCases := []struct {
+		name           string
+		live           map[string]interface{}
+		expectedResult objectDiffResult
+		expectDiff     bool
+		expectInDiff   string
+	}{
+		{
+			name:           "object not in cluster",
+			live:           nil,
+			expectedResult: objectNew,
+		},
+		{
+			name:           "only status, server metadata and defaulted fields differ",
+			live:           live(80),
+			expectedResult: objectUnchanged,
+		},
+		{
+			name:           "spec differs",
+			live:           live(8080),
+			expectedResult: objectChanged,
+			expectDiff:     true,
+		},
+		{
+			name:           "filter removed by the conversion",
+			live:           liveWithFilter,
+			expectedResult: objectChanged,
+			expectDiff:     true,
+			expectInDiff:   "RequestRedirect",
+		},
+		{
+			name:           "defaulted field with a non-default value",
+			live:           liveWithWeight,
+			expectedResult: objectChanged,
+			expectDiff:     true,
+		},
+		{
+			name:           "hostname removed by the conversion",
+			live:           liveWithHostname,
+			expectedResult: objectChanged,
+			expectDiff:     true,
+			expectInDiff:   "www.example.com",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			result, diff := diffObject(desired, tc.live)
+			if result != tc.expectedResult {
+				t.Errorf("Expected result %q, got %q", tc.expectedResult, result)
+			}
+			if tc.expectDiff != (diff != "") {
+				t.Errorf("Expected diff: %t, got %q", tc.expectDiff, diff)
+			}
+			if !strings.Contains(diff, tc.expectInDiff) {
+				t.Errorf("Expected %q in the diff, got %q", tc.expectInDiff, diff)
+			}
+		})
+	}
+}
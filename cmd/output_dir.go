@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// clusterScopedDir is the directory, relative to the output directory, that
+// holds cluster-scoped objects and objects generated without a namespace.
+// Namespace names are DNS labels, so it can never collide with a namespace.
+const clusterScopedDir = "_cluster"
+
+const kustomizationFileName = "kustomization.yaml"
+
+// kustomization is the subset of the kustomize Kustomization file written
+// for every namespace directory.
+type kustomization struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Resources  []string `json:"resources"`
+}
+
+// writeResultToDir writes every converted object to its own file, laid out as
+// <output-dir>/<namespace>/<kind>/<name>.<output>, and generates a
+// kustomization.yaml listing the files of each namespace directory.
+func (pr *PrintRunner) writeResultToDir(gatewayResources []i2gw.GatewayResources) error {
+	objects := gatewayResourcesObjects(gatewayResources)
+	if len(objects) == 0 {
+		msg := "No resources found"
+		if pr.namespaceFilter != "" {
+			msg = fmt.Sprintf("%s in %s namespace", msg, pr.namespaceFilter)
+		}
+		fmt.Println(msg)
+		return nil
+	}
+
+	extension := pr.outputFormat
+	if extension == "" {
+		extension = "yaml"
+	}
+
+	resourcesByDir := map[string][]string{}
+	for _, obj := range objects {
+		namespaceDir := obj.GetNamespace()
+		if namespaceDir == "" {
+			namespaceDir = clusterScopedDir
+		}
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		relativePath := filepath.Join(strings.ToLower(kind), fmt.Sprintf("%s.%s", obj.GetName(), extension))
+
+		if err := pr.writeObjectToFile(obj, filepath.Join(pr.outputDir, namespaceDir, relativePath)); err != nil {
+			return fmt.Errorf("failed to write %s %s: %w", kind, client.ObjectKeyFromObject(obj), err)
+		}
+		resourcesByDir[namespaceDir] = append(resourcesByDir[namespaceDir], filepath.ToSlash(relativePath))
+	}
+
+	for namespaceDir, resources := range resourcesByDir {
+		if err := writeKustomization(filepath.Join(pr.outputDir, namespaceDir), resources); err != nil {
+			return fmt.Errorf("failed to write %s for %s: %w", kustomizationFileName, namespaceDir, err)
+		}
+	}
+
+	fmt.Printf("Wrote %d resources to %s\n", len(objects), pr.outputDir)
+	return nil
+}
+
+// writeObjectToFile prints the object to the given path, creating the parent
+// directories if needed. A new printer is used for every file, so that YAML
+// documents are not prefixed with a separator.
+func (pr *PrintRunner) writeObjectToFile(obj client.Object, path string) error {
+	resourcePrinter, err := newResourcePrinter(pr.outputFormat)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return resourcePrinter.PrintObj(obj, f)
+}
+
+func writeKustomization(dir string, resources []string) error {
+	content, err := yaml.Marshal(kustomization{
+		APIVersion: "kustomize.config.k8s.io/v1beta1",
+		Kind:       "Kustomization",
+		Resources:  resources,
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, kustomizationFileName), content, 0o644)
+}
+
+// gatewayResourcesObjects flattens the given GatewayResources into a single list
+// of objects. The objects are grouped by kind, in the order they should be
+// created in the cluster, and sorted by namespace and name within each kind.
+func gatewayResourcesObjects(gatewayResources []i2gw.GatewayResources) []client.Object {
+	var gatewayClasses, gateways, httpRoutes, tlsRoutes, tcpRoutes, udpRoutes, referenceGrants, policies []client.Object
+
+	for _, r := range gatewayResources {
+		for _, gatewayClass := range r.GatewayClasses {
+			gatewayClass := gatewayClass
+			gatewayClasses = append(gatewayClasses, &gatewayClass)
+		}
+		for _, gateway := range r.Gateways {
+			gateway := gateway
+			gateways = append(gateways, &gateway)
+		}
+		for _, httpRoute := range r.HTTPRoutes {
+			httpRoute := httpRoute
+			httpRoutes = append(httpRoutes, &httpRoute)
+		}
+		for _, tlsRoute := range r.TLSRoutes {
+			tlsRoute := tlsRoute
+			tlsRoutes = append(tlsRoutes, &tlsRoute)
+		}
+		for _, tcpRoute := range r.TCPRoutes {
+			tcpRoute := tcpRoute
+			tcpRoutes = append(tcpRoutes, &tcpRoute)
+		}
+		for _, udpRoute := range r.UDPRoutes {
+			udpRoute := udpRoute
+			udpRoutes = append(udpRoutes, &udpRoute)
+		}
+		for _, referenceGrant := range r.ReferenceGrants {
+			referenceGrant := referenceGrant
+			referenceGrants = append(referenceGrants, &referenceGrant)
+		}
+		for _, policy := range r.Policies {
+			policy := policy
+			policies = append(policies, &policy)
+		}
+	}
+
+	var objects []client.Object
+	for _, group := range [][]client.Object{gatewayClasses, gateways, httpRoutes, tlsRoutes, tcpRoutes, udpRoutes, referenceGrants, policies} {
+		slices.SortFunc(group, func(a, b client.Object) int {
+			if a.GetNamespace() != b.GetNamespace() {
+				return cmp.Compare(a.GetNamespace(), b.GetNamespace())
+			}
+			return cmp.Compare(a.GetName(), b.GetName())
+		})
+		objects = append(objects, group...)
+	}
+	return objects
+}
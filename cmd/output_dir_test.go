@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func Test_writeResultToDir(t *testing.T) {
+	gateway := gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx"},
+		Spec:       gatewayv1.GatewaySpec{GatewayClassName: "nginx"},
+	}
+	gateway.SetGroupVersionKind(common.GatewayGVK)
+
+	routeA := gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a-example-com"}}
+	routeA.SetGroupVersionKind(common.HTTPRouteGVK)
+	routeB := gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "b-example-com"}}
+	routeB.SetGroupVersionKind(common.HTTPRouteGVK)
+
+	gatewayResources := []i2gw.GatewayResources{{
+		Gateways: map[types.NamespacedName]gatewayv1.Gateway{
+			{Namespace: "default", Name: "nginx"}: gateway,
+		},
+		HTTPRoutes: map[types.NamespacedName]gatewayv1.HTTPRoute{
+			{Namespace: "default", Name: "a-example-com"}: routeA,
+			{Namespace: "prod", Name: "b-example-com"}:    routeB,
+		},
+	}}
+
+	outputDir := t.TempDir()
+	pr := PrintRunner{outputFormat: "yaml", outputDir: outputDir}
+	if err := pr.writeResultToDir(gatewayResources); err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+
+	expectedFiles := []string{
+		"default/gateway/nginx.yaml",
+		"default/httproute/a-example-com.yaml",
+		"prod/httproute/b-example-com.yaml",
+	}
+	for _, file := range expectedFiles {
+		if _, err := os.Stat(filepath.Join(outputDir, file)); err != nil {
+			t.Errorf("Expected file %s to be written: %v", file, err)
+		}
+	}
+
+	expectedKustomizations := map[string][]string{
+		"default": {"gateway/nginx.yaml", "httproute/a-example-com.yaml"},
+		"prod":    {"httproute/b-example-com.yaml"},
+	}
+	for namespace, expectedResources := range expectedKustomizations {
+		content, err := os.ReadFile(filepath.Join(outputDir, namespace, kustomizationFileName))
+		if err != nil {
+			t.Fatalf("Expected kustomization for %s to be written: %v", namespace, err)
+		}
+		var k kustomization
+		if err = yaml.Unmarshal(content, &k); err != nil {
+			t.Fatalf("Failed to parse kustomization for %s: %v", namespace, err)
+		}
+		if diff := cmp.Diff(expectedResources, k.Resources); diff != "" {
+			t.Errorf("Unexpected kustomization resources for %s (-want +got):\n%s", namespace, diff)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// writeMigrationPlans writes the migration plan of every source object: the
+// objects it produced, the listeners its routes attach to, the features lost
+// in the conversion and the cut-over steps.
+func writeMigrationPlans(out io.Writer, plans []i2gw.MigrationPlan) {
+	if len(plans) == 0 {
+		fmt.Fprintln(out, "No source resources to migrate")
+		return
+	}
+
+	for _, plan := range plans {
+		fmt.Fprintf(out, "Migration plan for %s:\n", plan.Source)
+
+		fmt.Fprintln(out, "  Produced objects:")
+		for _, refs := range [][]i2gw.ObjectRef{plan.GatewayClasses, plan.Gateways, plan.ReferenceGrants, plan.Routes, plan.Policies} {
+			for _, ref := range refs {
+				fmt.Fprintf(out, "    - %s %s\n", ref.Kind, objectName(ref))
+			}
+		}
+
+		if len(plan.Listeners) > 0 {
+			fmt.Fprintln(out, "  Listeners:")
+			gateways := make([]types.NamespacedName, 0, len(plan.Listeners))
+			for gateway := range plan.Listeners {
+				gateways = append(gateways, gateway)
+			}
+			slices.SortFunc(gateways, func(a, b types.NamespacedName) int {
+				return strings.Compare(a.String(), b.String())
+			})
+			for _, gateway := range gateways {
+				names := make([]string, 0, len(plan.Listeners[gateway]))
+				for _, name := range plan.Listeners[gateway] {
+					names = append(names, string(name))
+				}
+				fmt.Fprintf(out, "    - Gateway %s: %s\n", gateway, strings.Join(names, ", "))
+			}
+		}
+
+		if len(plan.LostFeatures) > 0 {
+			fmt.Fprintln(out, "  Lost features:")
+			for _, n := range plan.LostFeatures {
+				fmt.Fprintf(out, "    - %s: %s\n", n.Type, n.Message)
+			}
+		}
+
+		fmt.Fprintln(out, "  Cut-over:")
+		for i, step := range plan.CutOverSteps() {
+			fmt.Fprintf(out, "    %d. %s\n", i+1, step)
+		}
+		fmt.Fprintln(out)
+	}
+}
+
+func objectName(ref i2gw.ObjectRef) string {
+	if ref.Namespace == "" {
+		return ref.Name
+	}
+	return ref.NamespacedName.String()
+}
@@ -18,13 +18,18 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"slices"
 	"strings"
 
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/render"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/client-go/tools/clientcmd"
@@ -36,9 +41,6 @@ import (
 	_ "github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/istio"
 	_ "github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/kong"
 	_ "github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/openapi3"
-
-	// Call init for notifications
-	_ "github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 )
 
 type PrintRunner struct {
@@ -46,8 +48,33 @@ type PrintRunner struct {
 	// Defaults to YAML.
 	outputFormat string
 
-	// The path to the input yaml config file. Value assigned via --input-file flag
-	inputFile string
+	// outputDir is the directory the converted resources are written to, one file
+	// per object, instead of printing them to stdout. Value assigned via --output-dir flag.
+	outputDir string
+
+	// plan indicates whether a migration plan is printed for every source
+	// resource instead of the converted resources. Value assigned via --plan flag.
+	plan bool
+
+	// The paths to the input yaml config files or directories, "-" standing for
+	// stdin. Value assigned via the repeatable --input-file flag
+	inputFiles []string
+
+	// The path to a local Helm chart rendered as input. Value assigned via
+	// --helm-chart flag.
+	helmChart string
+
+	// The Helm values files used to render the chart. Value assigned via the
+	// repeatable --values flag.
+	helmValues []string
+
+	// The release name used to render the chart. Value assigned via
+	// --helm-release-name flag.
+	helmReleaseName string
+
+	// The path to a kustomization directory built as input. Value assigned via
+	// --kustomize flag.
+	kustomizeDir string
 
 	// The namespace used to query Gateway API objects. Value assigned via
 	// --namespace/-n flag.
@@ -67,6 +94,64 @@ type PrintRunner struct {
 	// providers indicates which providers are used to execute convert action.
 	providers []string
 
+	// provenanceAnnotations indicates whether the generated resources are
+	// annotated with their source resources and the tool version. Value
+	// assigned via --provenance-annotations flag.
+	provenanceAnnotations bool
+
+	// The format the conversion notifications are written in. Value assigned
+	// via --notifications-format flag.
+	notificationsFormat string
+
+	// The file the conversion notifications are written to instead of stderr.
+	// Value assigned via --notifications-file flag.
+	notificationsFile string
+
+	// failOn is the notification severity at or above which the command fails.
+	// Value assigned via --fail-on flag.
+	failOn string
+
+	// maxConcurrency is the maximum number of providers reading or converting
+	// resources at the same time. Value assigned via --max-concurrency flag.
+	maxConcurrency int
+
+	// listenerConsolidation is how the per-host listeners of the Gateways
+	// generated from Ingresses are consolidated. Value assigned via
+	// --listener-consolidation flag.
+	listenerConsolidation string
+
+	// classMapping maps source classes to GatewayClasses, as
+	// <class>=<GatewayClass name> pairs. Value assigned via --class-mapping
+	// flag.
+	classMapping string
+
+	// classMappingFile is the path of a file mapping source classes to
+	// GatewayClasses and Gateways. Value assigned via --class-mapping-file
+	// flag.
+	classMappingFile string
+
+	// gatewayNamespace is the namespace of a single Gateway per class shared
+	// by all namespaces. Value assigned via --gateway-namespace flag.
+	gatewayNamespace string
+
+	// routeNamespaceSelector is the label selector of the namespaces whose
+	// routes may attach to the Gateways moved to another namespace. Value
+	// assigned via --route-namespace-selector flag.
+	routeNamespaceSelector string
+
+	// maxListenersPerGateway is the maximum number of listeners of the
+	// generated Gateways, beyond which they are split. Value assigned via
+	// --max-listeners-per-gateway flag.
+	maxListenersPerGateway int
+
+	// progress indicates whether the progress of the conversion is reported
+	// on stderr. Value assigned via --progress flag.
+	progress bool
+
+	// notificationAggr holds the notifications dispatched during the
+	// conversion.
+	notificationAggr *notifications.NotificationAggregator
+
 	// Provider specific flags --<provider>-<flag>.
 	providerSpecificFlags map[string]*string
 }
@@ -80,25 +165,178 @@ func (pr *PrintRunner) PrintGatewayAPIObjects(cmd *cobra.Command, _ []string) er
 	if err != nil {
 		return fmt.Errorf("failed to initialize resrouce printer: %w", err)
 	}
-	err = pr.initializeNamespaceFilter()
+
+	gatewayResources, err := pr.convert(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to initialize namespace filter: %w", err)
+		return err
 	}
 
-	gatewayResources, notificationTablesMap, err := i2gw.ToGatewayAPIResources(cmd.Context(), pr.namespaceFilter, pr.inputFile, pr.providers, pr.getProviderSpecificFlags())
+	if pr.outputDir != "" {
+		if err = pr.writeResultToDir(gatewayResources); err != nil {
+			return err
+		}
+	} else if !pr.plan {
+		pr.outputResult(gatewayResources)
+	}
+
+	if pr.plan {
+		writeMigrationPlans(os.Stdout, i2gw.BuildMigrationPlans(gatewayResources, pr.notificationAggr.Notifications))
+	}
+
+	return pr.checkFailOn()
+}
+
+// convert reads the ingresses and provider-specific resources, converts them to
+// Gateway API resources and prints the notifications generated by the providers.
+func (pr *PrintRunner) convert(cmd *cobra.Command) ([]i2gw.GatewayResources, error) {
+	err := pr.initializeNamespaceFilter()
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to initialize namespace filter: %w", err)
+	}
+
+	var inputs []i2gw.Input
+	if pr.readsFromFiles() {
+		inputs, err = pr.readInputs()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	classMapping, err := pr.getClassMapping()
+	if err != nil {
+		return nil, err
+	}
+
+	var routeNamespaceSelector *metav1.LabelSelector
+	if pr.routeNamespaceSelector != "" {
+		// The flag is validated before the command runs.
+		routeNamespaceSelector, _ = metav1.ParseToLabelSelector(pr.routeNamespaceSelector)
+	}
+
+	opts := i2gw.Options{
+		Providers:              pr.providers,
+		Namespace:              pr.namespaceFilter,
+		ProviderSpecificFlags:  pr.getProviderSpecificFlags(),
+		Inputs:                 inputs,
+		MaxConcurrency:         pr.maxConcurrency,
+		MaxListenersPerGateway: pr.maxListenersPerGateway,
+		ListenerConsolidation:  i2gw.ListenerConsolidation(pr.listenerConsolidation),
+		ClassMapping:           classMapping,
+		GatewayNamespace:       pr.gatewayNamespace,
+		RouteNamespaceSelector: routeNamespaceSelector,
+	}
+	if pr.progress {
+		opts.Progress = func(event i2gw.ProgressEvent) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "[%d/%d] %s: %s completed\n", event.Completed, event.Total, event.Provider, event.Stage)
+		}
+	}
+
+	result, err := i2gw.Convert(cmd.Context(), opts)
+	if err != nil {
+		return nil, err
 	}
+	gatewayResources := result.GatewayResources
+	pr.notificationAggr = &notifications.NotificationAggregator{Notifications: result.Notifications}
 
-	for _, table := range notificationTablesMap {
-		fmt.Println(table)
+	if pr.provenanceAnnotations {
+		for i := range gatewayResources {
+			i2gw.AddProvenanceAnnotations(&gatewayResources[i])
+		}
 	}
 
-	pr.outputResult(gatewayResources)
+	if err = pr.writeNotifications(cmd.ErrOrStderr()); err != nil {
+		return nil, err
+	}
 
+	return gatewayResources, nil
+}
+
+// checkFailOn returns an error if notifications at or above the --fail-on
+// severity were dispatched during the conversion.
+func (pr *PrintRunner) checkFailOn() error {
+	if pr.failOn == "" {
+		return nil
+	}
+	mType, err := parseFailOn(pr.failOn)
+	if err != nil {
+		return err
+	}
+	if count := pr.notificationAggr.CountAtLeast(mType); count > 0 {
+		return fmt.Errorf("the conversion produced %d notifications of severity %s or above", count, mType)
+	}
 	return nil
 }
 
+// failOnTypes are the notification types supported by --fail-on. Info
+// notifications are not, as every conversion produces some.
+var failOnTypes = []notifications.MessageType{notifications.WarningNotification, notifications.ErrorNotification}
+
+// parseFailOn parses the --fail-on severity, case-insensitively.
+func parseFailOn(s string) (notifications.MessageType, error) {
+	mType, err := notifications.ParseMessageType(s)
+	if err != nil || !slices.Contains(failOnTypes, mType) {
+		return "", fmt.Errorf("invalid --fail-on %q, supported values are %v", s, failOnTypes)
+	}
+	return mType, nil
+}
+
+// writeNotifications writes the notifications dispatched during the conversion
+// to the notifications file if set, or to stderr otherwise, so that they are
+// not mixed with the converted resources.
+func (pr *PrintRunner) writeNotifications(stderr io.Writer) error {
+	format := notifications.Format(pr.notificationsFormat)
+	if pr.notificationsFile == "" {
+		return pr.notificationAggr.WriteNotifications(stderr, format)
+	}
+
+	f, err := os.Create(pr.notificationsFile)
+	if err != nil {
+		return fmt.Errorf("failed to create notifications file: %w", err)
+	}
+	if err = pr.notificationAggr.WriteNotifications(f, format); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write notifications file: %w", err)
+	}
+	return f.Close()
+}
+
+// readsFromFiles returns whether the resources are read from input files,
+// Helm charts or kustomizations, rather than from the cluster.
+func (pr *PrintRunner) readsFromFiles() bool {
+	return len(pr.inputFiles) > 0 || pr.helmChart != "" || pr.kustomizeDir != ""
+}
+
+// readInputs reads the input files, and renders the Helm chart and the
+// kustomization, if any, into the inputs read by the providers.
+func (pr *PrintRunner) readInputs() ([]i2gw.Input, error) {
+	inputs, err := common.ReadInputFiles(pr.inputFiles, os.Stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	if pr.helmChart != "" {
+		namespace := pr.namespaceFilter
+		if namespace == "" {
+			namespace = "default"
+		}
+		manifests, err := render.HelmChart(pr.helmChart, pr.helmValues, pr.helmReleaseName, namespace)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, i2gw.Input{Name: pr.helmChart, Data: manifests})
+	}
+
+	if pr.kustomizeDir != "" {
+		manifests, err := render.Kustomization(pr.kustomizeDir)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, i2gw.Input{Name: pr.kustomizeDir, Data: manifests})
+	}
+
+	return inputs, nil
+}
+
 func (pr *PrintRunner) outputResult(gatewayResources []i2gw.GatewayResources) {
 	resourceCount := 0
 
@@ -179,6 +417,17 @@ func (pr *PrintRunner) outputResult(gatewayResources []i2gw.GatewayResources) {
 		}
 	}
 
+	for _, r := range gatewayResources {
+		resourceCount += len(r.Policies)
+		for _, policy := range r.Policies {
+			policy := policy
+			err := pr.resourcePrinter.PrintObj(&policy, os.Stdout)
+			if err != nil {
+				fmt.Printf("# Error printing %s %s: %v\n", policy.GetName(), policy.GetKind(), err)
+			}
+		}
+	}
+
 	if resourceCount == 0 {
 		msg := "No resources found"
 		if pr.namespaceFilter != "" {
@@ -191,17 +440,24 @@ func (pr *PrintRunner) outputResult(gatewayResources []i2gw.GatewayResources) {
 // initializeResourcePrinter assign a specific type of printers.ResourcePrinter
 // based on the outputFormat of the printRunner struct.
 func (pr *PrintRunner) initializeResourcePrinter() error {
-	switch pr.outputFormat {
+	resourcePrinter, err := newResourcePrinter(pr.outputFormat)
+	if err != nil {
+		return err
+	}
+	pr.resourcePrinter = resourcePrinter
+	return nil
+}
+
+// newResourcePrinter returns a new printers.ResourcePrinter for the given output format.
+func newResourcePrinter(outputFormat string) (printers.ResourcePrinter, error) {
+	switch outputFormat {
 	case "yaml", "":
-		pr.resourcePrinter = &printers.YAMLPrinter{}
-		return nil
+		return &printers.YAMLPrinter{}, nil
 	case "json":
-		pr.resourcePrinter = &printers.JSONPrinter{}
-		return nil
+		return &printers.JSONPrinter{}, nil
 	default:
-		return fmt.Errorf("%s is not a supported output format", pr.outputFormat)
+		return nil, fmt.Errorf("%s is not a supported output format", outputFormat)
 	}
-
 }
 
 // initializeNamespaceFilter initializes the correct namespace filter for resource processing with these scenarios:
@@ -219,7 +475,7 @@ func (pr *PrintRunner) initializeNamespaceFilter() error {
 	// If namespace flag is not specified, try to use the default namespace from the cluster
 	if pr.namespace == "" {
 		ns, err := getNamespaceInCurrentContext()
-		if err != nil && pr.inputFile == "" {
+		if err != nil && !pr.readsFromFiles() {
 			// When asked to read from the cluster, but getting the current namespace
 			// failed for whatever reason - do not process the request.
 			return err
@@ -242,23 +498,42 @@ func newPrintCommand() *cobra.Command {
 	// printCmd represents the print command. It prints HTTPRoutes and Gateways
 	// generated from Ingress resources.
 	var cmd = &cobra.Command{
-		Use:   "print",
-		Short: "Prints Gateway API objects generated from ingress and provider-specific resources.",
-		RunE:  pr.PrintGatewayAPIObjects,
-		PreRunE: func(cmd *cobra.Command, args []string) error {
-			openAPIExist := slices.Contains(pr.providers, "openapi3")
-			if openAPIExist && len(pr.providers) != 1 {
-				return fmt.Errorf("openapi3 must be the only provider when specified")
-			}
-			return nil
-		},
+		Use:     "print",
+		Short:   "Prints Gateway API objects generated from ingress and provider-specific resources.",
+		RunE:    pr.PrintGatewayAPIObjects,
+		PreRunE: pr.validateConversionFlags,
 	}
 
 	cmd.Flags().StringVarP(&pr.outputFormat, "output", "o", "yaml",
 		fmt.Sprintf(`Output format. One of: (%s).`, strings.Join(allowedFormats, ", ")))
 
-	cmd.Flags().StringVar(&pr.inputFile, "input-file", "",
-		`Path to the manifest file. When set, the tool will read ingresses from the file instead of reading from the cluster. Supported files are yaml and json.`)
+	cmd.Flags().StringVar(&pr.outputDir, "output-dir", "",
+		`If present, the converted resources are written to this directory as <namespace>/<kind>/<name>.<output>, with a kustomization.yaml per namespace, instead of being printed to stdout.`)
+
+	cmd.Flags().BoolVar(&pr.plan, "plan", false,
+		`If present, print a migration plan for every source resource instead of the converted resources: the objects it produced, the listeners its routes attach to, the features lost in the conversion and the cut-over steps. Can be combined with --output-dir.`)
+
+	pr.addConversionFlags(cmd)
+	return cmd
+}
+
+// addConversionFlags registers the flags that control which resources are read
+// and how they are converted. They are shared by all the commands running a conversion.
+func (pr *PrintRunner) addConversionFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&pr.inputFiles, "input-file", nil,
+		`Path to a manifest file, or to a directory whose yaml and json files are read recursively, or "-" to read from stdin. Can be repeated. When set, the tool will read ingresses from the files instead of reading from the cluster. Supported files are yaml and json.`)
+
+	cmd.Flags().StringVar(&pr.helmChart, "helm-chart", "",
+		`Path to a local Helm chart, rendered like helm template and read instead of the cluster. Only the dependencies vendored in the chart are used.`)
+
+	cmd.Flags().StringArrayVar(&pr.helmValues, "values", nil,
+		`Path to a values file used to render the Helm chart. Can be repeated.`)
+
+	cmd.Flags().StringVar(&pr.helmReleaseName, "helm-release-name", render.DefaultHelmReleaseName,
+		`The release name used to render the Helm chart.`)
+
+	cmd.Flags().StringVar(&pr.kustomizeDir, "kustomize", "",
+		`Path to a kustomization directory, built like kustomize build and read instead of the cluster.`)
 
 	cmd.Flags().StringVarP(&pr.namespace, "namespace", "n", "",
 		`If present, the namespace scope for this CLI request.`)
@@ -270,6 +545,42 @@ if specified with --namespace.`)
 	cmd.Flags().StringSliceVar(&pr.providers, "providers", []string{},
 		fmt.Sprintf("If present, the tool will try to convert only resources related to the specified providers, supported values are %v.", i2gw.GetSupportedProviders()))
 
+	cmd.Flags().BoolVar(&pr.provenanceAnnotations, "provenance-annotations", false,
+		fmt.Sprintf(`If present, annotate every generated resource with the resources it was converted from (%s) and the version of the tool (%s).`, i2gw.SourceAnnotation, i2gw.VersionAnnotation))
+
+	cmd.Flags().StringVar(&pr.notificationsFormat, "notifications-format", string(notifications.TableFormat),
+		fmt.Sprintf(`The format of the conversion notifications, one of %v.`, notifications.SupportedFormats))
+
+	cmd.Flags().StringVar(&pr.notificationsFile, "notifications-file", "",
+		`If present, the conversion notifications are written to this file instead of stderr.`)
+
+	cmd.Flags().StringVar(&pr.failOn, "fail-on", "",
+		`If present, the command exits with a non-zero code when a conversion notification of this severity or above is produced, either warning or error.`)
+
+	cmd.Flags().IntVar(&pr.maxConcurrency, "max-concurrency", i2gw.DefaultMaxConcurrency,
+		`The maximum number of providers reading or converting resources at the same time.`)
+
+	cmd.Flags().StringVar(&pr.listenerConsolidation, "listener-consolidation", "",
+		fmt.Sprintf(`If present, how the per-host listeners of the Gateways generated from Ingresses are consolidated, either %s or %s. The routes then attach to the consolidated listeners by sectionName.`, i2gw.WildcardListenerConsolidation, i2gw.HostnamelessListenerConsolidation))
+
+	cmd.Flags().StringVar(&pr.classMapping, "class-mapping", "",
+		`If present, a comma-separated list of <class>=<GatewayClass name> pairs, e.g. nginx=envoy-gateway,internal=envoy-internal, setting the GatewayClass of the Gateways converted from the resources of every class. The class of Ingresses is their ingress class, and the class of the other resources is the GatewayClass name generated by their provider, e.g. istio.`)
+
+	cmd.Flags().StringVar(&pr.classMappingFile, "class-mapping-file", "",
+		`If present, the path of a YAML file mapping every class to its gatewayClassName, and optionally the gatewayName and gatewayNamespace of its Gateway and the controllerName of a generated GatewayClass.`)
+
+	cmd.Flags().StringVar(&pr.gatewayNamespace, "gateway-namespace", "",
+		`If present, the namespace of a single Gateway per class shared by the routes of all namespaces, instead of a Gateway per class and namespace. The routes reference it with its namespace, its listeners allow the routes of other namespaces to attach, and ReferenceGrants are generated for the TLS Secrets of the other namespaces.`)
+
+	cmd.Flags().StringVar(&pr.routeNamespaceSelector, "route-namespace-selector", "",
+		`If present, the label selector, e.g. gateway-access=true, of the namespaces whose routes may attach to the Gateways moved to another namespace by --gateway-namespace or the class mapping. The routes of all namespaces may attach otherwise.`)
+
+	cmd.Flags().IntVar(&pr.maxListenersPerGateway, "max-listeners-per-gateway", 0,
+		fmt.Sprintf(`If present, the Gateways with more listeners are split into Gateways named <name>-1 to <name>-N, and the routes are attached to the Gateways holding their listeners. Cannot exceed %d.`, i2gw.MaxListenersPerGateway))
+
+	cmd.Flags().BoolVar(&pr.progress, "progress", false,
+		`If present, the progress of every provider is reported on stderr.`)
+
 	pr.providerSpecificFlags = make(map[string]*string)
 	for provider, flags := range i2gw.GetProviderSpecificFlagDefinitions() {
 		for _, flag := range flags {
@@ -280,7 +591,47 @@ if specified with --namespace.`)
 
 	_ = cmd.MarkFlagRequired("providers")
 	cmd.MarkFlagsMutuallyExclusive("namespace", "all-namespaces")
-	return cmd
+	cmd.MarkFlagsMutuallyExclusive("class-mapping", "class-mapping-file")
+}
+
+// validateConversionFlags checks that the requested providers can be used
+// together, and that the input flags are consistent.
+func (pr *PrintRunner) validateConversionFlags(_ *cobra.Command, _ []string) error {
+	openAPIExist := slices.Contains(pr.providers, "openapi3")
+	if openAPIExist && len(pr.providers) != 1 {
+		return fmt.Errorf("openapi3 must be the only provider when specified")
+	}
+	if len(pr.helmValues) > 0 && pr.helmChart == "" {
+		return fmt.Errorf("--values can only be used with --helm-chart")
+	}
+	if !slices.Contains(i2gw.ListenerConsolidations, i2gw.ListenerConsolidation(pr.listenerConsolidation)) {
+		return fmt.Errorf("unsupported listener consolidation %q, supported values are %s and %s", pr.listenerConsolidation, i2gw.WildcardListenerConsolidation, i2gw.HostnamelessListenerConsolidation)
+	}
+	if pr.classMapping != "" {
+		if _, err := i2gw.ParseClassMapping(pr.classMapping); err != nil {
+			return fmt.Errorf("invalid --class-mapping: %w", err)
+		}
+	}
+	if pr.routeNamespaceSelector != "" {
+		if _, err := metav1.ParseToLabelSelector(pr.routeNamespaceSelector); err != nil {
+			return fmt.Errorf("invalid --route-namespace-selector: %w", err)
+		}
+	}
+	if pr.maxListenersPerGateway < 0 || pr.maxListenersPerGateway > i2gw.MaxListenersPerGateway {
+		return fmt.Errorf("--max-listeners-per-gateway must be between 1 and %d", i2gw.MaxListenersPerGateway)
+	}
+	if pr.maxConcurrency < 1 {
+		return fmt.Errorf("--max-concurrency must be at least 1")
+	}
+	if !slices.Contains(notifications.SupportedFormats, notifications.Format(pr.notificationsFormat)) {
+		return fmt.Errorf("unsupported notifications format %q, supported formats are %v", pr.notificationsFormat, notifications.SupportedFormats)
+	}
+	if pr.failOn != "" {
+		if _, err := parseFailOn(pr.failOn); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // getNamespaceInCurrentContext returns the namespace in the current active context of the user.
@@ -293,6 +644,19 @@ func getNamespaceInCurrentContext() (string, error) {
 	return currentNamespace, err
 }
 
+// getClassMapping returns the class mapping of the --class-mapping or
+// --class-mapping-file flag, if any.
+func (pr *PrintRunner) getClassMapping() (i2gw.ClassMapping, error) {
+	switch {
+	case pr.classMappingFile != "":
+		return i2gw.ReadClassMappingFile(pr.classMappingFile)
+	case pr.classMapping != "":
+		return i2gw.ParseClassMapping(pr.classMapping)
+	default:
+		return nil, nil
+	}
+}
+
 // getProviderSpecificFlags returns the provider specific flags input by the user.
 // The flags are returned in a map where the key is the provider name and the value is a map of flag name to flag value.
 func (pr *PrintRunner) getProviderSpecificFlags() map[string]map[string]string {
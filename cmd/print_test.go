@@ -17,6 +17,7 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"log"
 	"os"
@@ -25,6 +26,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"k8s.io/cli-runtime/pkg/printers"
 )
 
@@ -294,3 +296,76 @@ func Test_getProviderSpecificFlags(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkFailOn(t *testing.T) {
+	notificationAggr := &notifications.NotificationAggregator{
+		Notifications: map[string][]notifications.Notification{
+			"istio": {
+				notifications.NewNotification(notifications.InfoNotification, "converted"),
+				notifications.NewNotification(notifications.WarningNotification, "ignoring field"),
+			},
+		},
+	}
+
+	testCases := []struct {
+		failOn         string
+		expectingError bool
+		invalid        bool
+	}{
+		{failOn: "", expectingError: false},
+		{failOn: "error", expectingError: false},
+		{failOn: "warning", expectingError: true},
+		{failOn: "WARNING", expectingError: true},
+		{failOn: "info", expectingError: true, invalid: true},
+		{failOn: "fatal", expectingError: true, invalid: true},
+	}
+
+	for _, tc := range testCases {
+		pr := PrintRunner{failOn: tc.failOn, notificationAggr: notificationAggr}
+		err := pr.checkFailOn()
+		if (err != nil) != tc.expectingError {
+			t.Errorf("checkFailOn() with --fail-on=%q returned %v, expecting error: %t", tc.failOn, err, tc.expectingError)
+		}
+		if _, parseErr := parseFailOn(tc.failOn); tc.failOn != "" && (parseErr != nil) != tc.invalid {
+			t.Errorf("parseFailOn(%q) returned %v, expecting invalid: %t", tc.failOn, parseErr, tc.invalid)
+		}
+	}
+}
+
+func Test_writeNotifications(t *testing.T) {
+	notificationAggr := &notifications.NotificationAggregator{
+		Notifications: map[string][]notifications.Notification{
+			"istio": {notifications.NewNotification(notifications.WarningNotification, "ignoring field")},
+		},
+	}
+
+	t.Run("stderr by default", func(t *testing.T) {
+		var stderr bytes.Buffer
+		pr := PrintRunner{notificationsFormat: string(notifications.JSONFormat), notificationAggr: notificationAggr}
+		if err := pr.writeNotifications(&stderr); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !bytes.Contains(stderr.Bytes(), []byte("ignoring field")) {
+			t.Errorf("Expected the notifications on stderr, got %q", stderr.String())
+		}
+	})
+
+	t.Run("notifications file", func(t *testing.T) {
+		var stderr bytes.Buffer
+		file := filepath.Join(t.TempDir(), "notifications.json")
+		pr := PrintRunner{notificationsFormat: string(notifications.JSONFormat), notificationsFile: file, notificationAggr: notificationAggr}
+		if err := pr.writeNotifications(&stderr); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if stderr.Len() != 0 {
+			t.Errorf("Expected nothing on stderr, got %q", stderr.String())
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Contains(content, []byte("ignoring field")) {
+			t.Errorf("Expected the notifications in the file, got %q", content)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// ClassMapping maps source classes to the GatewayClass, and optionally the
+// Gateway, their resources are converted to. The source class of a Gateway
+// generated from Ingresses is their ingress class, see
+// GatewayResources.SourceClasses, and the class of any other Gateway is its
+// generated GatewayClass name, e.g. istio.
+type ClassMapping map[string]GatewayClassMapping
+
+// GatewayClassMapping is the target of a source class.
+type GatewayClassMapping struct {
+	// GatewayClassName is the GatewayClass of the Gateways of the class.
+	GatewayClassName string `json:"gatewayClassName"`
+
+	// GatewayName is the name of the Gateway of the class. The generated
+	// name is kept when empty.
+	GatewayName string `json:"gatewayName,omitempty"`
+
+	// GatewayNamespace is the namespace of the Gateway of the class. The
+	// generated namespace is kept when empty.
+	GatewayNamespace string `json:"gatewayNamespace,omitempty"`
+
+	// ControllerName, when set, is the controllerName of a GatewayClass
+	// generated along with the Gateways.
+	ControllerName string `json:"controllerName,omitempty"`
+}
+
+// ParseClassMapping parses a comma-separated list of
+// <source class>=<GatewayClass name> pairs, e.g.
+// nginx=envoy-gateway,internal=envoy-internal.
+func ParseClassMapping(s string) (ClassMapping, error) {
+	mapping := ClassMapping{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		class, gatewayClassName, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid class mapping %q, expected <class>=<GatewayClass name>", pair)
+		}
+		class = strings.TrimSpace(class)
+		if _, ok := mapping[class]; ok {
+			return nil, fmt.Errorf("class %q is mapped more than once", class)
+		}
+		mapping[class] = GatewayClassMapping{GatewayClassName: strings.TrimSpace(gatewayClassName)}
+	}
+	return mapping, mapping.Validate()
+}
+
+// ReadClassMappingFile reads a class mapping from a YAML or JSON file, mapping
+// every source class to its GatewayClassMapping, e.g.
+//
+//	nginx:
+//	  gatewayClassName: envoy-gateway
+//	  gatewayName: envoy
+//	  gatewayNamespace: envoy-gateway-system
+//	  controllerName: gateway.envoyproxy.io/gatewayclass-controller
+func ReadClassMappingFile(path string) (ClassMapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the class mapping file: %w", err)
+	}
+	mapping := ClassMapping{}
+	if err = yaml.UnmarshalStrict(data, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse the class mapping file: %w", err)
+	}
+	return mapping, mapping.Validate()
+}
+
+// Validate checks that every class is mapped to valid object names.
+func (m ClassMapping) Validate() error {
+	for _, class := range sortedClasses(m) {
+		target := m[class]
+		if class == "" {
+			return fmt.Errorf("the class mapping of GatewayClass %q has no class", target.GatewayClassName)
+		}
+		if errs := validation.IsDNS1123Subdomain(target.GatewayClassName); len(errs) > 0 {
+			return fmt.Errorf("invalid GatewayClass name %q for class %q: %s", target.GatewayClassName, class, strings.Join(errs, ", "))
+		}
+		if target.GatewayName != "" {
+			if errs := validation.IsDNS1123Subdomain(target.GatewayName); len(errs) > 0 {
+				return fmt.Errorf("invalid Gateway name %q for class %q: %s", target.GatewayName, class, strings.Join(errs, ", "))
+			}
+		}
+		if target.GatewayNamespace != "" {
+			if errs := validation.IsDNS1123Label(target.GatewayNamespace); len(errs) > 0 {
+				return fmt.Errorf("invalid Gateway namespace %q for class %q: %s", target.GatewayNamespace, class, strings.Join(errs, ", "))
+			}
+		}
+		if target.ControllerName != "" && !strings.Contains(target.ControllerName, "/") {
+			return fmt.Errorf("invalid controllerName %q for class %q: expected a domain-prefixed path, e.g. example.net/gateway-controller", target.ControllerName, class)
+		}
+	}
+	return nil
+}
+
+// sortedClasses returns the classes of the mapping in lexical order.
+func sortedClasses(m ClassMapping) []string {
+	classes := make([]string, 0, len(m))
+	for class := range m {
+		classes = append(classes, class)
+	}
+	slices.Sort(classes)
+	return classes
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_ParseClassMapping(t *testing.T) {
+	testCases := []struct {
+		name            string
+		value           string
+		expectedMapping ClassMapping
+		expectingError  bool
+	}{
+		{
+			name:  "several classes",
+			value: "nginx=envoy-gateway, internal=envoy-internal",
+			expectedMapping: ClassMapping{
+				"nginx":    {GatewayClassName: "envoy-gateway"},
+				"internal": {GatewayClassName: "envoy-internal"},
+			},
+		},
+		{
+			name:           "missing GatewayClass",
+			value:          "nginx",
+			expectingError: true,
+		},
+		{
+			name:           "invalid GatewayClass name",
+			value:          "nginx=Envoy_Gateway",
+			expectingError: true,
+		},
+		{
+			name:           "class mapped twice",
+			value:          "nginx=envoy-gateway,nginx=envoy-internal",
+			expectingError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mapping, err := ParseClassMapping(tc.value)
+			if tc.expectingError {
+				if err == nil {
+					t.Fatalf("Expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedMapping, mapping); diff != "" {
+				t.Errorf("Unexpected class mapping, diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ReadClassMappingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "class-mapping.yaml")
+	content := `nginx:
+  gatewayClassName: envoy-gateway
+  gatewayName: envoy
+  gatewayNamespace: envoy-gateway-system
+  controllerName: gateway.envoyproxy.io/gatewayclass-controller
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write the class mapping file: %v", err)
+	}
+
+	mapping, err := ReadClassMappingFile(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedMapping := ClassMapping{
+		"nginx": {
+			GatewayClassName: "envoy-gateway",
+			GatewayName:      "envoy",
+			GatewayNamespace: "envoy-gateway-system",
+			ControllerName:   "gateway.envoyproxy.io/gatewayclass-controller",
+		},
+	}
+	if diff := cmp.Diff(expectedMapping, mapping); diff != "" {
+		t.Errorf("Unexpected class mapping, diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,338 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Options configures a conversion run.
+//
+// The resources are read from at most one of Client, Inputs, InputFile,
+// Objects and Reader. When none of them is set, they are read from the cluster
+// of the current kubeconfig context.
+type Options struct {
+	// Providers are the names of the providers running the conversion, see
+	// GetSupportedProviders.
+	Providers []string
+
+	// Namespace restricts the conversion to the resources of this namespace.
+	// The resources of all namespaces are converted when empty.
+	Namespace string
+
+	// ProviderSpecificFlags are the values of the provider-specific flags, by
+	// provider and flag name.
+	ProviderSpecificFlags map[string]map[string]string
+
+	// Client is the client used to read the resources from a cluster.
+	Client client.Client
+
+	// Inputs are the sources the resources are read from, every one of them
+	// read on its own by the providers.
+	Inputs []Input
+
+	// InputFile is the path of a manifest file the resources are read from.
+	InputFile string
+
+	// Objects are the resources to convert. They must have their apiVersion
+	// and kind set.
+	Objects []runtime.Object
+
+	// Reader is a stream of YAML or JSON manifests the resources are read
+	// from.
+	Reader io.Reader
+
+	// MaxConcurrency is the maximum number of providers reading or converting
+	// resources at the same time. DefaultMaxConcurrency is used when it is not
+	// positive.
+	MaxConcurrency int
+
+	// ListenerConsolidation is how the per-host listeners of the Gateways
+	// generated from Ingresses are consolidated.
+	ListenerConsolidation ListenerConsolidation
+
+	// ClassMapping maps the classes of the converted resources to the
+	// GatewayClass and Gateway they are converted to, see PlaceGateways.
+	ClassMapping ClassMapping
+
+	// GatewayNamespace, when set, is the namespace of a single Gateway per
+	// class shared by all namespaces, see GatewayPlacement.
+	GatewayNamespace string
+
+	// RouteNamespaceSelector, when set, selects the namespaces whose routes
+	// may attach to the Gateways moved to another namespace, see
+	// GatewayPlacement.
+	RouteNamespaceSelector *metav1.LabelSelector
+
+	// MaxListenersPerGateway, when positive, is the maximum number of
+	// listeners of the generated Gateways. The Gateways with more listeners
+	// are split, see ShardGateways. It cannot exceed MaxListenersPerGateway.
+	MaxListenersPerGateway int
+
+	// Progress is called every time a provider completes a stage of the run.
+	// The calls are serialized.
+	Progress func(ProgressEvent)
+}
+
+// DefaultMaxConcurrency is the default maximum number of providers reading or
+// converting resources at the same time.
+const DefaultMaxConcurrency = 4
+
+// Stage is a stage of a conversion run.
+type Stage string
+
+const (
+	// ReadStage is the reading of the resources of a provider.
+	ReadStage Stage = "read"
+
+	// ConvertStage is the conversion of the resources of a provider.
+	ConvertStage Stage = "convert"
+)
+
+// ProgressEvent reports that a provider completed a stage of a conversion run.
+type ProgressEvent struct {
+	Provider ProviderName
+	Stage    Stage
+
+	// Completed is the number of providers that completed the stage so far,
+	// out of Total.
+	Completed int
+	Total     int
+}
+
+// Result is the outcome of a conversion run.
+type Result struct {
+	// GatewayResources are the Gateway API resources generated by the
+	// providers, merged into a single GatewayResources, see
+	// MergeGatewayResources.
+	GatewayResources []GatewayResources
+
+	// Notifications are the notifications dispatched during the run, by
+	// provider.
+	Notifications map[string][]notifications.Notification
+}
+
+// Convert reads the resources described by the options and converts them to
+// Gateway API resources. It is safe to call concurrently: the notifications of
+// every run are collected by their own aggregator.
+//
+// The providers read and convert their resources concurrently, and the run
+// stops as soon as ctx is done.
+//
+// The notifications of the run are returned even when the conversion fails.
+func Convert(ctx context.Context, opts Options) (Result, error) {
+	notificationAggr := notifications.NewNotificationAggregator()
+	result, err := convert(ctx, opts, notificationAggr)
+	result.Notifications = notificationAggr.Snapshot()
+	return result, err
+}
+
+func convert(ctx context.Context, opts Options, notificationAggr *notifications.NotificationAggregator) (Result, error) {
+	if opts.MaxListenersPerGateway > MaxListenersPerGateway {
+		return Result{}, fmt.Errorf("a Gateway cannot have more than %d listeners", MaxListenersPerGateway)
+	}
+
+	if !slices.Contains(ListenerConsolidations, opts.ListenerConsolidation) {
+		return Result{}, fmt.Errorf("unsupported listener consolidation %q, supported values are %v", opts.ListenerConsolidation, ListenerConsolidations)
+	}
+
+	if err := opts.ClassMapping.Validate(); err != nil {
+		return Result{}, err
+	}
+	if opts.GatewayNamespace != "" {
+		if errs := validation.IsDNS1123Label(opts.GatewayNamespace); len(errs) > 0 {
+			return Result{}, fmt.Errorf("invalid Gateway namespace %q: %s", opts.GatewayNamespace, strings.Join(errs, ", "))
+		}
+	}
+
+	set := 0
+	for _, isSet := range []bool{opts.Client != nil, opts.Inputs != nil, opts.InputFile != "", opts.Objects != nil, opts.Reader != nil} {
+		if isSet {
+			set++
+		}
+	}
+	if set > 1 {
+		return Result{}, fmt.Errorf("at most one of Client, Inputs, InputFile, Objects and Reader can be set")
+	}
+
+	inputs, err := readInputs(opts)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var clusterClient client.Client
+	if inputs == nil {
+		cl := opts.Client
+		if cl == nil {
+			cl, err = NewClusterClient()
+			if err != nil {
+				return Result{}, err
+			}
+		}
+		clusterClient = cl
+		if opts.Namespace != "" {
+			clusterClient = client.NewNamespacedClient(cl, opts.Namespace)
+		}
+	}
+
+	providerByName, err := constructProviders(&ProviderConf{
+		Client:                clusterClient,
+		Namespace:             opts.Namespace,
+		ProviderSpecificFlags: opts.ProviderSpecificFlags,
+		Notifications:         notificationAggr,
+		ListenerConsolidation: opts.ListenerConsolidation,
+		GatewayNamespace:      opts.GatewayNamespace,
+	}, opts.Providers)
+	if err != nil {
+		return Result{}, err
+	}
+
+	maxConcurrency := opts.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = DefaultMaxConcurrency
+	}
+	names := sortedProviderNames(providerByName)
+	progress := &progressReporter{report: opts.Progress, total: len(names)}
+
+	err = forEachProvider(ctx, names, maxConcurrency, func(ctx context.Context, _ int, name ProviderName) error {
+		if inputs != nil {
+			if err := providerByName[name].ReadResourcesFromInputs(ctx, inputs); err != nil {
+				return fmt.Errorf("failed to read %s resources from the inputs: %w", name, err)
+			}
+		} else if err := providerByName[name].ReadResourcesFromCluster(ctx); err != nil {
+			return fmt.Errorf("failed to read %s resources from the cluster: %w", name, err)
+		}
+		progress.completed(name, ReadStage)
+		return nil
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	gatewayResources := make([]GatewayResources, len(names))
+	providerErrs := make([]field.ErrorList, len(names))
+	err = forEachProvider(ctx, names, maxConcurrency, func(_ context.Context, i int, name ProviderName) error {
+		gatewayResources[i], providerErrs[i] = providerByName[name].ToGatewayAPI()
+		progress.completed(name, ConvertStage)
+		return nil
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	var errs field.ErrorList
+	for _, conversionErrs := range providerErrs {
+		errs = append(errs, conversionErrs...)
+	}
+	if len(errs) > 0 {
+		return Result{}, aggregatedErrs(errs)
+	}
+
+	// Several providers may generate the same Gateways, e.g. one per
+	// GatewayClass, so their resources are merged rather than concatenated.
+	providers := make([]string, 0, len(names))
+	for _, name := range names {
+		providers = append(providers, string(name))
+	}
+	mergedGatewayResources, errs := mergeGatewayResources(providers, gatewayResources)
+	if len(errs) > 0 {
+		return Result{}, aggregatedErrs(errs)
+	}
+
+	// The placement may move Gateways together, so the Gateways are split
+	// once they are placed.
+	errs = PlaceGateways(&mergedGatewayResources, GatewayPlacement{
+		ClassMapping:           opts.ClassMapping,
+		Namespace:              opts.GatewayNamespace,
+		RouteNamespaceSelector: opts.RouteNamespaceSelector,
+	})
+	if len(errs) > 0 {
+		return Result{}, aggregatedErrs(errs)
+	}
+
+	errs = ShardGateways(&mergedGatewayResources, opts.MaxListenersPerGateway)
+	errs = append(errs, validateGatewayLimits(mergedGatewayResources.Gateways)...)
+	if len(errs) > 0 {
+		return Result{}, aggregatedErrs(errs)
+	}
+
+	return Result{GatewayResources: []GatewayResources{mergedGatewayResources}}, nil
+}
+
+// forEachProvider calls fn with the index and name of every provider, with at
+// most maxConcurrency calls running at the same time. No call is started once
+// ctx is done or a call failed, and the first error is returned.
+func forEachProvider(ctx context.Context, names []ProviderName, maxConcurrency int, fn func(ctx context.Context, i int, name ProviderName) error) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrency)
+	for i, name := range names {
+		i, name := i, name
+		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return fn(ctx, i, name)
+		})
+	}
+	return g.Wait()
+}
+
+// progressReporter counts the providers that completed every stage, and
+// serializes the calls to report.
+type progressReporter struct {
+	mutex   sync.Mutex
+	report  func(ProgressEvent)
+	total   int
+	byStage map[Stage]int
+}
+
+func (p *progressReporter) completed(name ProviderName, stage Stage) {
+	if p.report == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.byStage == nil {
+		p.byStage = map[Stage]int{}
+	}
+	p.byStage[stage]++
+	p.report(ProgressEvent{Provider: name, Stage: stage, Completed: p.byStage[stage], Total: p.total})
+}
+
+// sortedProviderNames returns the names of the providers in lexical order.
+func sortedProviderNames(providerByName map[ProviderName]Provider) []ProviderName {
+	names := make([]ProviderName, 0, len(providerByName))
+	for name := range providerByName {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,378 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const testProviderName ProviderName = "test"
+
+// testProvider converts every Ingress to an HTTPRoute of the same name, and
+// notifies about every Ingress.
+type testProvider struct {
+	conf      *ProviderConf
+	ingresses []networkingv1.Ingress
+}
+
+func (p *testProvider) ReadResourcesFromCluster(ctx context.Context) error {
+	var ingressList networkingv1.IngressList
+	if err := p.conf.Client.List(ctx, &ingressList); err != nil {
+		return err
+	}
+	p.ingresses = ingressList.Items
+	return nil
+}
+
+func (p *testProvider) ReadResourcesFromInputs(_ context.Context, inputs []Input) error {
+	for _, input := range inputs {
+		if err := p.readInput(input); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *testProvider) readInput(input Input) error {
+	if input.Objects != nil {
+		for _, obj := range input.Objects {
+			var ingress networkingv1.Ingress
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), &ingress); err != nil {
+				return err
+			}
+			if ingress.Kind == "Ingress" {
+				p.ingresses = append(p.ingresses, ingress)
+			}
+		}
+		return nil
+	}
+
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(input.Data), 4096)
+	for {
+		var ingress networkingv1.Ingress
+		if err := decoder.Decode(&ingress); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if ingress.Kind == "Ingress" {
+			p.ingresses = append(p.ingresses, ingress)
+		}
+	}
+}
+
+func (p *testProvider) ToGatewayAPI() (GatewayResources, field.ErrorList) {
+	gatewayResources := GatewayResources{HTTPRoutes: map[types.NamespacedName]gatewayv1.HTTPRoute{}}
+	for _, ingress := range p.ingresses {
+		ingress := ingress
+		key := types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}
+		gatewayResources.HTTPRoutes[key] = gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: ingress.Namespace, Name: ingress.Name}}
+		notification := notifications.NewNotification(notifications.InfoNotification, fmt.Sprintf("converted %s", key), &ingress)
+		p.conf.NotificationAggregator().DispatchNotification(notification, string(testProviderName))
+	}
+	return gatewayResources, nil
+}
+
+func registerTestProvider(t *testing.T) {
+	t.Helper()
+	ProviderConstructorByName[testProviderName] = func(conf *ProviderConf) Provider {
+		return &testProvider{conf: conf}
+	}
+	t.Cleanup(func() { delete(ProviderConstructorByName, testProviderName) })
+}
+
+func testIngress(namespace, name string) *networkingv1.Ingress {
+	return &networkingv1.Ingress{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "Ingress"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+	}
+}
+
+func Test_Convert(t *testing.T) {
+	registerTestProvider(t)
+
+	testCases := []struct {
+		name           string
+		opts           Options
+		expectedRoutes []types.NamespacedName
+		expectingError bool
+	}{
+		{
+			name: "objects",
+			opts: Options{Objects: []runtime.Object{testIngress("default", "web")}},
+			expectedRoutes: []types.NamespacedName{
+				{Namespace: "default", Name: "web"},
+			},
+		},
+		{
+			name: "reader",
+			opts: Options{Reader: strings.NewReader("apiVersion: networking.k8s.io/v1\nkind: Ingress\nmetadata:\n  name: api\n  namespace: prod\n")},
+			expectedRoutes: []types.NamespacedName{
+				{Namespace: "prod", Name: "api"},
+			},
+		},
+		{
+			name: "inputs",
+			opts: Options{Inputs: []Input{
+				{Name: "web.yaml", Data: []byte("apiVersion: networking.k8s.io/v1\nkind: Ingress\nmetadata:\n  name: web\n  namespace: default\n")},
+				{Name: "api.yaml", Data: []byte("apiVersion: networking.k8s.io/v1\nkind: Ingress\nmetadata:\n  name: api\n  namespace: prod\n")},
+			}},
+			expectedRoutes: []types.NamespacedName{
+				{Namespace: "default", Name: "web"},
+				{Namespace: "prod", Name: "api"},
+			},
+		},
+		{
+			name: "client restricted to the namespace",
+			opts: Options{
+				Namespace: "default",
+				Client:    fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(testIngress("default", "web"), testIngress("prod", "api")).Build(),
+			},
+			expectedRoutes: []types.NamespacedName{
+				{Namespace: "default", Name: "web"},
+			},
+		},
+		{
+			name:           "object without kind",
+			opts:           Options{Objects: []runtime.Object{&networkingv1.Ingress{}}},
+			expectingError: true,
+		},
+		{
+			name: "several sources",
+			opts: Options{
+				Objects: []runtime.Object{testIngress("default", "web")},
+				Reader:  strings.NewReader(""),
+			},
+			expectingError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tc.opts.Providers = []string{string(testProviderName)}
+			result, err := Convert(context.Background(), tc.opts)
+			if tc.expectingError {
+				if err == nil {
+					t.Fatalf("Expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if len(result.GatewayResources) != 1 {
+				t.Fatalf("Expected GatewayResources from 1 provider, got %d", len(result.GatewayResources))
+			}
+			routes := result.GatewayResources[0].HTTPRoutes
+			if len(routes) != len(tc.expectedRoutes) {
+				t.Fatalf("Expected %d HTTPRoutes, got %d", len(tc.expectedRoutes), len(routes))
+			}
+			for _, key := range tc.expectedRoutes {
+				if _, ok := routes[key]; !ok {
+					t.Errorf("Expected HTTPRoute %s, got %v", key, routes)
+				}
+			}
+			if got := len(result.Notifications[string(testProviderName)]); got != len(tc.expectedRoutes) {
+				t.Errorf("Expected %d notifications, got %d", len(tc.expectedRoutes), got)
+			}
+		})
+	}
+}
+
+func Test_ConvertConcurrently(t *testing.T) {
+	registerTestProvider(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := fmt.Sprintf("tenant-%d", i)
+			result, err := Convert(context.Background(), Options{
+				Providers: []string{string(testProviderName)},
+				Objects:   []runtime.Object{testIngress(name, "web")},
+			})
+			if err != nil {
+				t.Errorf("Expected no error, got %v", err)
+				return
+			}
+
+			// Every run only gets its own notifications.
+			runNotifications := result.Notifications[string(testProviderName)]
+			if len(runNotifications) != 1 || runNotifications[0].Message != fmt.Sprintf("converted %s/web", name) {
+				t.Errorf("Expected the notification of %s only, got %v", name, runNotifications)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func Test_ConvertMergesProviders(t *testing.T) {
+	registerTestProvider(t)
+	const copyProviderName ProviderName = "test-copy"
+	ProviderConstructorByName[copyProviderName] = ProviderConstructorByName[testProviderName]
+	t.Cleanup(func() { delete(ProviderConstructorByName, copyProviderName) })
+
+	// Both providers generate the same HTTPRoute, which is output once.
+	result, err := Convert(context.Background(), Options{
+		Providers: []string{string(testProviderName), string(copyProviderName)},
+		Objects:   []runtime.Object{testIngress("default", "web")},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.GatewayResources) != 1 {
+		t.Fatalf("Expected merged GatewayResources, got %d", len(result.GatewayResources))
+	}
+	if routes := result.GatewayResources[0].HTTPRoutes; len(routes) != 1 {
+		t.Errorf("Expected 1 HTTPRoute, got %v", routes)
+	}
+}
+
+// countingProvider records the maximum number of providers reading their
+// resources at the same time.
+type countingProvider struct {
+	running    *atomic.Int32
+	maxRunning *atomic.Int32
+	read       bool
+}
+
+func (p *countingProvider) ReadResourcesFromCluster(ctx context.Context) error {
+	return p.ReadResourcesFromInputs(ctx, nil)
+}
+
+func (p *countingProvider) ReadResourcesFromInputs(_ context.Context, _ []Input) error {
+	running := p.running.Add(1)
+	defer p.running.Add(-1)
+	for {
+		maxRunning := p.maxRunning.Load()
+		if running <= maxRunning || p.maxRunning.CompareAndSwap(maxRunning, running) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	p.read = true
+	return nil
+}
+
+func (p *countingProvider) ToGatewayAPI() (GatewayResources, field.ErrorList) {
+	return GatewayResources{}, nil
+}
+
+// registerCountingProviders registers count countingProviders, and returns
+// their names and the providers once constructed.
+func registerCountingProviders(t *testing.T, count int, maxRunning *atomic.Int32) ([]string, map[ProviderName]*countingProvider) {
+	t.Helper()
+	var (
+		names     []string
+		running   atomic.Int32
+		mutex     sync.Mutex
+		providers = map[ProviderName]*countingProvider{}
+	)
+	for i := 0; i < count; i++ {
+		name := ProviderName(fmt.Sprintf("counting-%d", i))
+		names = append(names, string(name))
+		ProviderConstructorByName[name] = func(*ProviderConf) Provider {
+			p := &countingProvider{running: &running, maxRunning: maxRunning}
+			mutex.Lock()
+			defer mutex.Unlock()
+			providers[name] = p
+			return p
+		}
+		t.Cleanup(func() { delete(ProviderConstructorByName, name) })
+	}
+	return names, providers
+}
+
+func Test_ConvertMaxConcurrency(t *testing.T) {
+	var maxRunning atomic.Int32
+	names, _ := registerCountingProviders(t, 5, &maxRunning)
+
+	var events []ProgressEvent
+	_, err := Convert(context.Background(), Options{
+		Providers:      names,
+		Objects:        []runtime.Object{testIngress("default", "web")},
+		MaxConcurrency: 2,
+		Progress: func(event ProgressEvent) {
+			events = append(events, event)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got := maxRunning.Load(); got > 2 {
+		t.Errorf("Expected at most 2 providers reading at the same time, got %d", got)
+	}
+
+	completed := map[Stage]int{}
+	for _, event := range events {
+		completed[event.Stage]++
+		if event.Completed != completed[event.Stage] || event.Total != len(names) {
+			t.Errorf("Expected %s event %d/%d, got %d/%d", event.Stage, completed[event.Stage], len(names), event.Completed, event.Total)
+		}
+	}
+	for _, stage := range []Stage{ReadStage, ConvertStage} {
+		if completed[stage] != len(names) {
+			t.Errorf("Expected %d %s events, got %d", len(names), stage, completed[stage])
+		}
+	}
+}
+
+func Test_ConvertCancelled(t *testing.T) {
+	var maxRunning atomic.Int32
+	names, providers := registerCountingProviders(t, 3, &maxRunning)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Convert(ctx, Options{
+		Providers: names,
+		Objects:   []runtime.Object{testIngress("default", "web")},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected %v, got %v", context.Canceled, err)
+	}
+	for name, p := range providers {
+		if p.read {
+			t.Errorf("Expected provider %s not to read resources once cancelled", name)
+		}
+	}
+}
@@ -19,11 +19,15 @@ package i2gw
 import (
 	"context"
 	"fmt"
-	"maps"
+	"slices"
+	"strings"
 
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -31,74 +35,36 @@ import (
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
+// ToGatewayAPIResources converts the resources of the given providers, read
+// from inputFile or, when empty, from the cluster, and returns them along with
+// the notification tables by provider.
+//
+// Deprecated: use Convert, which also accepts an injected client or objects
+// and returns the notifications.
 func ToGatewayAPIResources(ctx context.Context, namespace string, inputFile string, providers []string, providerSpecificFlags map[string]map[string]string) ([]GatewayResources, map[string]string, error) {
-	var clusterClient client.Client
-
-	if inputFile == "" {
-		conf, err := config.GetConfig()
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get client config: %w", err)
-		}
-
-		cl, err := client.New(conf, client.Options{})
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create client: %w", err)
-		}
-		clusterClient = client.NewNamespacedClient(cl, namespace)
-	}
-
-	providerByName, err := constructProviders(&ProviderConf{
-		Client:                clusterClient,
+	result, err := Convert(ctx, Options{
+		Providers:             providers,
 		Namespace:             namespace,
 		ProviderSpecificFlags: providerSpecificFlags,
-	}, providers)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	if inputFile != "" {
-		if err = readProviderResourcesFromFile(ctx, providerByName, inputFile); err != nil {
-			return nil, nil, err
-		}
-	} else {
-		if err = readProviderResourcesFromCluster(ctx, providerByName); err != nil {
-			return nil, nil, err
-		}
-	}
-
-	var (
-		gatewayResources []GatewayResources
-		errs             field.ErrorList
-	)
-	for _, provider := range providerByName {
-		providerGatewayResources, conversionErrs := provider.ToGatewayAPI()
-		errs = append(errs, conversionErrs...)
-		gatewayResources = append(gatewayResources, providerGatewayResources)
-	}
-	notificationTablesMap := notifications.NotificationAggr.CreateNotificationTables()
-	if len(errs) > 0 {
-		return nil, notificationTablesMap, aggregatedErrs(errs)
-	}
-
-	return gatewayResources, notificationTablesMap, nil
+		InputFile:             inputFile,
+	})
+	aggregator := notifications.NotificationAggregator{Notifications: result.Notifications}
+	return result.GatewayResources, aggregator.CreateNotificationTables(), err
 }
 
-func readProviderResourcesFromFile(ctx context.Context, providerByName map[ProviderName]Provider, inputFile string) error {
-	for name, provider := range providerByName {
-		if err := provider.ReadResourcesFromFile(ctx, inputFile); err != nil {
-			return fmt.Errorf("failed to read %s resources from file: %w", name, err)
-		}
+// NewClusterClient creates a controller-runtime client for the cluster of the
+// current kubeconfig context.
+func NewClusterClient() (client.Client, error) {
+	conf, err := config.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client config: %w", err)
 	}
-	return nil
-}
 
-func readProviderResourcesFromCluster(ctx context.Context, providerByName map[ProviderName]Provider) error {
-	for name, provider := range providerByName {
-		if err := provider.ReadResourcesFromCluster(ctx); err != nil {
-			return fmt.Errorf("failed to read %s resources from the cluster: %w", name, err)
-		}
+	cl, err := client.New(conf, client.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
-	return nil
+	return cl, nil
 }
 
 // constructProviders constructs a map of concrete Provider implementations
@@ -138,14 +104,31 @@ func GetSupportedProviders() []string {
 
 // MergeGatewayResources accept multiple GatewayResources and create a unique Resource struct
 // built as follows:
-//   - GatewayClasses, *Routes, and ReferenceGrants are grouped into the same maps
+//   - GatewayClasses, *Routes, ReferenceGrants and Policies are grouped into the same maps.
+//     Objects with the same NamespacedName must be identical, but for the
+//     ReferenceGrants of TLS Secrets, see GrantTLSSecretReference, which are
+//     merged.
 //   - Gateways may have the same NamespaceName even if they come from different
 //     ingresses, as they have a their GatewayClass' name as name. For this reason,
 //     if there are mutiple gateways named the same, their listeners are merged into
-//     a unique Gateway.
+//     a unique Gateway, see mergeGateways. Listeners conflicting as defined by
+//     the Gateway API are reported as errors.
+//
+// A conflict between two objects or listeners is reported as an error naming
+// the origins of both. The merged Gateways may exceed the maximum number of
+// listeners of a Gateway, which is checked once the conversion completes, so
+// that they can be split with ShardGateways.
 //
 // This behavior is likely to change after https://github.com/kubernetes-sigs/gateway-api/pull/1863 takes place.
 func MergeGatewayResources(gatewayResources ...GatewayResources) (GatewayResources, field.ErrorList) {
+	return mergeGatewayResources(make([]string, len(gatewayResources)), gatewayResources)
+}
+
+// mergeGatewayResources merges the GatewayResources as MergeGatewayResources
+// does. providers are the names of the providers that generated every
+// GatewayResources, empty when unknown, and are part of the origins reported
+// on conflicts.
+func mergeGatewayResources(providers []string, gatewayResources []GatewayResources) (GatewayResources, field.ErrorList) {
 	mergedGatewayResources := GatewayResources{
 		Gateways:        make(map[types.NamespacedName]gatewayv1.Gateway),
 		GatewayClasses:  make(map[types.NamespacedName]gatewayv1.GatewayClass),
@@ -154,47 +137,354 @@ func MergeGatewayResources(gatewayResources ...GatewayResources) (GatewayResourc
 		TCPRoutes:       make(map[types.NamespacedName]gatewayv1alpha2.TCPRoute),
 		UDPRoutes:       make(map[types.NamespacedName]gatewayv1alpha2.UDPRoute),
 		ReferenceGrants: make(map[types.NamespacedName]gatewayv1beta1.ReferenceGrant),
+		Policies:        make(map[ObjectRef]unstructured.Unstructured),
+		Sources:         Sources{},
+		SourceClasses:   make(map[types.NamespacedName]string),
 	}
+	origins := newMergeOrigins()
 	var errs field.ErrorList
-	mergedGatewayResources.Gateways, errs = mergeGateways(gatewayResources)
+	for i, gr := range gatewayResources {
+		gr := gr
+		originOf := func(obj client.Object) string {
+			return describeOrigin(providers[i], gr.Sources[ObjectRefFor(obj)])
+		}
+		renames, gatewayErrs := mergeGateways(mergedGatewayResources.Gateways, gr.Gateways, originOf, origins)
+		errs = append(errs, gatewayErrs...)
+		errs = append(errs, mergeObjects(mergedGatewayResources.GatewayClasses, gr.GatewayClasses, "GatewayClass", originOf, origins.objects)...)
+		httpRoutes := renameSectionNames(gr.HTTPRoutes, func(r *gatewayv1.HTTPRoute) *gatewayv1.CommonRouteSpec { return &r.Spec.CommonRouteSpec }, renames)
+		errs = append(errs, mergeObjects(mergedGatewayResources.HTTPRoutes, httpRoutes, "HTTPRoute", originOf, origins.objects)...)
+		tlsRoutes := renameSectionNames(gr.TLSRoutes, func(r *gatewayv1alpha2.TLSRoute) *gatewayv1.CommonRouteSpec { return &r.Spec.CommonRouteSpec }, renames)
+		errs = append(errs, mergeObjects(mergedGatewayResources.TLSRoutes, tlsRoutes, "TLSRoute", originOf, origins.objects)...)
+		tcpRoutes := renameSectionNames(gr.TCPRoutes, func(r *gatewayv1alpha2.TCPRoute) *gatewayv1.CommonRouteSpec { return &r.Spec.CommonRouteSpec }, renames)
+		errs = append(errs, mergeObjects(mergedGatewayResources.TCPRoutes, tcpRoutes, "TCPRoute", originOf, origins.objects)...)
+		udpRoutes := renameSectionNames(gr.UDPRoutes, func(r *gatewayv1alpha2.UDPRoute) *gatewayv1.CommonRouteSpec { return &r.Spec.CommonRouteSpec }, renames)
+		errs = append(errs, mergeObjects(mergedGatewayResources.UDPRoutes, udpRoutes, "UDPRoute", originOf, origins.objects)...)
+		referenceGrants := mergeTLSSecretReferenceGrants(mergedGatewayResources.ReferenceGrants, gr.ReferenceGrants)
+		errs = append(errs, mergeObjects(mergedGatewayResources.ReferenceGrants, referenceGrants, "ReferenceGrant", originOf, origins.objects)...)
+		errs = append(errs, mergePolicies(mergedGatewayResources.Policies, gr.Policies, originOf, origins.objects)...)
+		for obj, sources := range gr.Sources {
+			mergedGatewayResources.Sources.Add(obj, sources...)
+		}
+		for nn, class := range gr.SourceClasses {
+			if _, ok := mergedGatewayResources.SourceClasses[nn]; !ok {
+				mergedGatewayResources.SourceClasses[nn] = class
+			}
+		}
+	}
 	if len(errs) > 0 {
 		return GatewayResources{}, errs
 	}
-	for _, gr := range gatewayResources {
-		maps.Copy(mergedGatewayResources.GatewayClasses, gr.GatewayClasses)
-		maps.Copy(mergedGatewayResources.HTTPRoutes, gr.HTTPRoutes)
-		maps.Copy(mergedGatewayResources.TLSRoutes, gr.TLSRoutes)
-		maps.Copy(mergedGatewayResources.TCPRoutes, gr.TCPRoutes)
-		maps.Copy(mergedGatewayResources.UDPRoutes, gr.UDPRoutes)
-		maps.Copy(mergedGatewayResources.ReferenceGrants, gr.ReferenceGrants)
+	return mergedGatewayResources, nil
+}
+
+// mergeOrigins records the origins of the merged objects and listeners, to
+// report both origins of a conflict.
+type mergeOrigins struct {
+	// objects are the origins of the objects by kind and NamespacedName.
+	objects map[string]string
+	// listeners are the origins of the listeners by Gateway and listener name.
+	listeners map[types.NamespacedName]map[gatewayv1.SectionName]string
+}
+
+func newMergeOrigins() *mergeOrigins {
+	return &mergeOrigins{
+		objects:   map[string]string{},
+		listeners: map[types.NamespacedName]map[gatewayv1.SectionName]string{},
+	}
+}
+
+// describeOrigin describes the origin of an object as its provider, when
+// known, followed by the objects it was converted from.
+func describeOrigin(provider string, sources []ObjectRef) string {
+	refs := make([]string, 0, len(sources))
+	for _, source := range sources {
+		refs = append(refs, source.String())
+	}
+	slices.Sort(refs)
+
+	switch {
+	case provider != "" && len(refs) > 0:
+		return fmt.Sprintf("%s (%s)", provider, strings.Join(refs, ", "))
+	case provider != "":
+		return provider
+	case len(refs) > 0:
+		return strings.Join(refs, ", ")
+	default:
+		return "an unknown origin"
+	}
+}
+
+// mergeObjects copies the objects to merged. An object already in merged must
+// be identical, or a conflict is reported.
+func mergeObjects[T any, PT interface {
+	*T
+	client.Object
+}](merged, objects map[types.NamespacedName]T, kind string, originOf func(client.Object) string, origins map[string]string) field.ErrorList {
+	var errs field.ErrorList
+	for _, nn := range sortedKeys(objects) {
+		obj := objects[nn]
+		origin := originOf(PT(&obj))
+		originKey := kind + "/" + nn.String()
+		if existing, ok := merged[nn]; ok {
+			if !apiequality.Semantic.DeepEqual(existing, obj) {
+				fieldPath := field.NewPath(fmt.Sprintf("%s/%s", nn.Namespace, nn.Name))
+				errs = append(errs, field.Invalid(fieldPath, nn.String(), fmt.Sprintf("conflicting %s definitions from %s and from %s", kind, origins[originKey], origin)))
+			}
+			continue
+		}
+		merged[nn] = obj
+		origins[originKey] = origin
+	}
+	return errs
+}
+
+// mergePolicies merges the policies into merged as mergeObjects does, the
+// policies being keyed by kind as well as by NamespacedName.
+func mergePolicies(merged, policies map[ObjectRef]unstructured.Unstructured, originOf func(client.Object) string, origins map[string]string) field.ErrorList {
+	refs := make([]ObjectRef, 0, len(policies))
+	for ref := range policies {
+		refs = append(refs, ref)
+	}
+	slices.SortFunc(refs, compareObjectRefs)
+
+	var errs field.ErrorList
+	for _, ref := range refs {
+		policy := policies[ref]
+		origin := originOf(&policy)
+		originKey := ref.String()
+		if existing, ok := merged[ref]; ok {
+			if !apiequality.Semantic.DeepEqual(existing, policy) {
+				fieldPath := field.NewPath(fmt.Sprintf("%s/%s", ref.Namespace, ref.Name))
+				errs = append(errs, field.Invalid(fieldPath, ref.NamespacedName.String(), fmt.Sprintf("conflicting %s definitions from %s and from %s", ref.Kind, origins[originKey], origin)))
+			}
+			continue
+		}
+		merged[ref] = policy
+		origins[originKey] = origin
+	}
+	return errs
+}
+
+// mergeTLSSecretReferenceGrants merges the ReferenceGrants of the TLS Secrets
+// of every namespace, see GrantTLSSecretReference, into the one of merged,
+// and returns the other ReferenceGrants.
+func mergeTLSSecretReferenceGrants(merged, grants map[types.NamespacedName]gatewayv1beta1.ReferenceGrant) map[types.NamespacedName]gatewayv1beta1.ReferenceGrant {
+	others := make(map[types.NamespacedName]gatewayv1beta1.ReferenceGrant, len(grants))
+	for nn, grant := range grants {
+		if nn.Name != TLSSecretsReferenceGrantName {
+			others[nn] = grant
+			continue
+		}
+		for _, from := range grant.Spec.From {
+			for _, to := range grant.Spec.To {
+				if to.Name != nil {
+					GrantTLSSecretReference(merged, string(from.Namespace), types.NamespacedName{Namespace: nn.Namespace, Name: string(*to.Name)})
+				}
+			}
+		}
+	}
+	return others
+}
+
+// listenerRenames are the listeners renamed while merging, by Gateway and
+// original listener name.
+type listenerRenames map[types.NamespacedName]map[gatewayv1.SectionName]gatewayv1.SectionName
+
+func (r listenerRenames) add(gateway types.NamespacedName, from, to gatewayv1.SectionName) {
+	if r[gateway] == nil {
+		r[gateway] = map[gatewayv1.SectionName]gatewayv1.SectionName{}
 	}
-	return mergedGatewayResources, errs
+	r[gateway][from] = to
 }
 
-func mergeGateways(gatewaResources []GatewayResources) (map[types.NamespacedName]gatewayv1.Gateway, field.ErrorList) {
-	newGateways := map[types.NamespacedName]gatewayv1.Gateway{}
-	errs := field.ErrorList{}
+// mergeGateways merges the gateways into merged. The listeners of gateways
+// with the same NamespacedName are merged into a unique Gateway:
+//   - a listener identical to a merged one, but for its name, is merged into
+//     it;
+//   - a listener conflicting with a merged one, i.e. on the same port with an
+//     incompatible protocol, or with the same port, protocol and hostname, is
+//     reported as a conflict;
+//   - a listener named as a merged one is renamed with a numeric suffix.
+//
+// The listeners merged into or renamed to another name are returned, so that
+// the routes attached to them by sectionName can be updated.
+func mergeGateways(merged, gateways map[types.NamespacedName]gatewayv1.Gateway, originOf func(client.Object) string, origins *mergeOrigins) (listenerRenames, field.ErrorList) {
+	renames := listenerRenames{}
+	var errs field.ErrorList
+	for _, nn := range sortedKeys(gateways) {
+		g := gateways[nn]
+		origin := originOf(&g)
+		fieldPath := field.NewPath(fmt.Sprintf("%s/%s", nn.Namespace, nn.Name)).Child("spec")
+
+		existingGateway, ok := merged[nn]
+		if !ok {
+			merged[nn] = g
+			origins.objects["Gateway/"+nn.String()] = origin
+			origins.listeners[nn] = map[gatewayv1.SectionName]string{}
+			for _, listener := range g.Spec.Listeners {
+				origins.listeners[nn][listener.Name] = origin
+			}
+			continue
+		}
+
+		if existingGateway.Spec.GatewayClassName != g.Spec.GatewayClassName {
+			errs = append(errs, field.Invalid(fieldPath.Child("gatewayClassName"), g.Spec.GatewayClassName,
+				fmt.Sprintf("conflicting gatewayClassName %q from %s and %q from %s", existingGateway.Spec.GatewayClassName, origins.objects["Gateway/"+nn.String()], g.Spec.GatewayClassName, origin)))
+		}
 
-	for _, gr := range gatewaResources {
-		for _, g := range gr.Gateways {
-			nn := types.NamespacedName{Namespace: g.Namespace, Name: g.Name}
-			if existingGateway, ok := newGateways[nn]; ok {
-				g.Spec.Listeners = append(g.Spec.Listeners, existingGateway.Spec.Listeners...)
-				g.Spec.Addresses = append(g.Spec.Addresses, existingGateway.Spec.Addresses...)
+		// The listeners and addresses are copied before being appended to,
+		// as they may share their backing arrays with the merged GatewayResources.
+		existingGateway.Spec.Listeners = slices.Clone(existingGateway.Spec.Listeners)
+		for _, listener := range g.Spec.Listeners {
+			if i := slices.IndexFunc(existingGateway.Spec.Listeners, func(l gatewayv1.Listener) bool { return listenersEqualButName(l, listener) }); i >= 0 {
+				if name := existingGateway.Spec.Listeners[i].Name; name != listener.Name {
+					renames.add(nn, listener.Name, name)
+				}
+				continue
 			}
-			newGateways[nn] = g
-			// 64 is the maximum number of listeners a Gateway can have
-			if len(g.Spec.Listeners) > 64 {
-				fieldPath := field.NewPath(fmt.Sprintf("%s/%s", nn.Namespace, nn.Name)).Child("spec").Child("listeners")
-				errs = append(errs, field.Invalid(fieldPath, g, "error while merging gateway listeners: a gateway cannot have more than 64 listeners"))
+
+			conflicting := false
+			for i, existingListener := range existingGateway.Spec.Listeners {
+				if reason := listenersConflict(existingListener, listener); reason != "" {
+					errs = append(errs, field.Invalid(fieldPath.Child("listeners").Index(i), listener.Name,
+						fmt.Sprintf("listener %q from %s conflicts with listener %q from %s: %s", existingListener.Name, origins.listeners[nn][existingListener.Name], listener.Name, origin, reason)))
+					conflicting = true
+				}
+			}
+			if conflicting {
+				continue
+			}
+
+			if name := uniqueListenerName(existingGateway.Spec.Listeners, listener.Name); name != listener.Name {
+				renames.add(nn, listener.Name, name)
+				listener.Name = name
 			}
-			// 16 is the maximum number of addresses a Gateway can have
-			if len(g.Spec.Addresses) > 16 {
-				fieldPath := field.NewPath(fmt.Sprintf("%s/%s", nn.Namespace, nn.Name)).Child("spec").Child("addresses")
-				errs = append(errs, field.Invalid(fieldPath, g, "error while merging gateway listeners: a gateway cannot have more than 16 addresses"))
+			existingGateway.Spec.Listeners = append(existingGateway.Spec.Listeners, listener)
+			origins.listeners[nn][listener.Name] = origin
+		}
+
+		existingGateway.Spec.Addresses = slices.Clone(existingGateway.Spec.Addresses)
+		for _, address := range g.Spec.Addresses {
+			if !slices.ContainsFunc(existingGateway.Spec.Addresses, func(a gatewayv1.GatewayAddress) bool { return apiequality.Semantic.DeepEqual(a, address) }) {
+				existingGateway.Spec.Addresses = append(existingGateway.Spec.Addresses, address)
 			}
 		}
+		merged[nn] = existingGateway
 	}
+	return renames, errs
+}
 
-	return newGateways, errs
+// listenersEqualButName returns whether the listeners are identical, but for
+// their names.
+func listenersEqualButName(a, b gatewayv1.Listener) bool {
+	b.Name = a.Name
+	return apiequality.Semantic.DeepEqual(a, b)
+}
+
+// listenersConflict returns why two different listeners of a Gateway conflict,
+// as defined by the Gateway API, or an empty string if they don't: listeners
+// on the same port must have compatible protocols, and a unique hostname for
+// the same protocol.
+func listenersConflict(a, b gatewayv1.Listener) string {
+	if a.Port != b.Port {
+		return ""
+	}
+	if !protocolsCompatible(a.Protocol, b.Protocol) {
+		return fmt.Sprintf("protocols %s and %s cannot share port %d", a.Protocol, b.Protocol, a.Port)
+	}
+	if a.Protocol == b.Protocol && ptr.Equal(a.Hostname, b.Hostname) {
+		hostname := "no hostname"
+		if a.Hostname != nil {
+			hostname = fmt.Sprintf("hostname %s", *a.Hostname)
+		}
+		return fmt.Sprintf("same port %d, protocol %s and %s", a.Port, a.Protocol, hostname)
+	}
+	return ""
+}
+
+// protocolsCompatible returns whether listeners with the given protocols can
+// share a port. HTTPS and TLS listeners can share a port, and UDP listeners
+// don't conflict with the listeners of the other protocols, which use TCP.
+func protocolsCompatible(a, b gatewayv1.ProtocolType) bool {
+	if a == gatewayv1.UDPProtocolType || b == gatewayv1.UDPProtocolType {
+		return true
+	}
+	if a == gatewayv1.TLSProtocolType {
+		a = gatewayv1.HTTPSProtocolType
+	}
+	if b == gatewayv1.TLSProtocolType {
+		b = gatewayv1.HTTPSProtocolType
+	}
+	return a == b
+}
+
+// uniqueListenerName returns name, or name with the first numeric suffix
+// making it unique among the listeners.
+func uniqueListenerName(listeners []gatewayv1.Listener, name gatewayv1.SectionName) gatewayv1.SectionName {
+	taken := func(n gatewayv1.SectionName) bool {
+		return slices.ContainsFunc(listeners, func(l gatewayv1.Listener) bool { return l.Name == n })
+	}
+	unique := name
+	for i := 2; taken(unique); i++ {
+		unique = gatewayv1.SectionName(fmt.Sprintf("%s-%d", name, i))
+	}
+	return unique
+}
+
+// renameSectionNames returns a copy of the routes, whose parentRefs use the
+// new names of the renamed listeners.
+func renameSectionNames[T any, PT interface {
+	*T
+	client.Object
+}](routes map[types.NamespacedName]T, commonSpec func(PT) *gatewayv1.CommonRouteSpec, renames listenerRenames) map[types.NamespacedName]T {
+	if len(renames) == 0 {
+		return routes
+	}
+	renamed := make(map[types.NamespacedName]T, len(routes))
+	for nn, route := range routes {
+		route := route
+		spec := commonSpec(&route)
+		spec.ParentRefs = slices.Clone(spec.ParentRefs)
+		for i, parentRef := range spec.ParentRefs {
+			gateway, ok := parentRefGateway(nn.Namespace, parentRef)
+			if !ok || parentRef.SectionName == nil {
+				continue
+			}
+			if name, ok := renames[gateway][*parentRef.SectionName]; ok {
+				spec.ParentRefs[i].SectionName = ptr.To(name)
+			}
+		}
+		renamed[nn] = route
+	}
+	return renamed
+}
+
+// validateGatewayLimits checks that the gateways are within the limits of the
+// number of listeners and addresses of a Gateway.
+func validateGatewayLimits(gateways map[types.NamespacedName]gatewayv1.Gateway) field.ErrorList {
+	var errs field.ErrorList
+	for _, nn := range sortedKeys(gateways) {
+		g := gateways[nn]
+		if len(g.Spec.Listeners) > MaxListenersPerGateway {
+			fieldPath := field.NewPath(fmt.Sprintf("%s/%s", nn.Namespace, nn.Name)).Child("spec").Child("listeners")
+			errs = append(errs, field.TooMany(fieldPath, len(g.Spec.Listeners), MaxListenersPerGateway))
+		}
+		if len(g.Spec.Addresses) > maxAddressesPerGateway {
+			fieldPath := field.NewPath(fmt.Sprintf("%s/%s", nn.Namespace, nn.Name)).Child("spec").Child("addresses")
+			errs = append(errs, field.TooMany(fieldPath, len(g.Spec.Addresses), maxAddressesPerGateway))
+		}
+	}
+	return errs
+}
+
+// sortedKeys returns the keys of the map in lexical order.
+func sortedKeys[T any](objects map[types.NamespacedName]T) []types.NamespacedName {
+	keys := make([]types.NamespacedName, 0, len(objects))
+	for nn := range objects {
+		keys = append(keys, nn)
+	}
+	slices.SortFunc(keys, func(a, b types.NamespacedName) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return keys
 }
@@ -18,10 +18,18 @@ package i2gw
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 func Test_constructProviders(t *testing.T) {
@@ -93,3 +101,226 @@ func Test_GetSupportedProviders(t *testing.T) {
 		}
 	})
 }
+
+func Test_mergeGatewayResources(t *testing.T) {
+	gatewayKey := types.NamespacedName{Namespace: "default", Name: "nginx"}
+	ingressGVK := schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}
+	gatewayGVK := gatewayv1.SchemeGroupVersion.WithKind("Gateway")
+	routeGVK := gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute")
+
+	listener := func(name string, port gatewayv1.PortNumber, protocol gatewayv1.ProtocolType, hostname string) gatewayv1.Listener {
+		return gatewayv1.Listener{
+			Name:     gatewayv1.SectionName(name),
+			Port:     port,
+			Protocol: protocol,
+			Hostname: ptr.To(gatewayv1.Hostname(hostname)),
+		}
+	}
+	withCertificate := func(l gatewayv1.Listener, secretName string) gatewayv1.Listener {
+		l.TLS = &gatewayv1.GatewayTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{{Name: gatewayv1.ObjectName(secretName)}}}
+		return l
+	}
+	gateway := func(className string, listeners ...gatewayv1.Listener) gatewayv1.Gateway {
+		return gatewayv1.Gateway{
+			TypeMeta:   metav1.TypeMeta{APIVersion: gatewayv1.GroupVersion.String(), Kind: "Gateway"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: gatewayKey.Namespace, Name: gatewayKey.Name},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: gatewayv1.ObjectName(className),
+				Listeners:        listeners,
+			},
+		}
+	}
+	route := func(name, hostname, sectionName string) gatewayv1.HTTPRoute {
+		return gatewayv1.HTTPRoute{
+			TypeMeta:   metav1.TypeMeta{APIVersion: gatewayv1.GroupVersion.String(), Kind: "HTTPRoute"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: gatewayKey.Namespace, Name: name},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{
+						Name:        gatewayv1.ObjectName(gatewayKey.Name),
+						SectionName: ptr.To(gatewayv1.SectionName(sectionName)),
+					}},
+				},
+				Hostnames: []gatewayv1.Hostname{gatewayv1.Hostname(hostname)},
+			},
+		}
+	}
+	// resources returns the GatewayResources converted from the Ingress of the
+	// given name.
+	resources := func(ingressName string, g gatewayv1.Gateway, routes ...gatewayv1.HTTPRoute) GatewayResources {
+		ingressRef := ObjectRef{GroupVersionKind: ingressGVK, NamespacedName: types.NamespacedName{Namespace: "default", Name: ingressName}}
+		gr := GatewayResources{
+			Gateways:   map[types.NamespacedName]gatewayv1.Gateway{gatewayKey: g},
+			HTTPRoutes: map[types.NamespacedName]gatewayv1.HTTPRoute{},
+			Sources: Sources{
+				{GroupVersionKind: gatewayGVK, NamespacedName: gatewayKey}: {ingressRef},
+			},
+		}
+		for _, r := range routes {
+			routeKey := types.NamespacedName{Namespace: r.Namespace, Name: r.Name}
+			gr.HTTPRoutes[routeKey] = r
+			gr.Sources.Add(ObjectRef{GroupVersionKind: routeGVK, NamespacedName: routeKey}, ingressRef)
+		}
+		return gr
+	}
+
+	testCases := []struct {
+		name                 string
+		gatewayResources     []GatewayResources
+		expectedListeners    []gatewayv1.Listener
+		expectedSectionNames map[string]string
+		expectedErrors       []string
+	}{
+		{
+			name: "identical listeners and routes are merged",
+			gatewayResources: []GatewayResources{
+				resources("a", gateway("nginx", listener("foo-http", 80, gatewayv1.HTTPProtocolType, "foo.com")), route("foo", "foo.com", "foo-http")),
+				resources("b", gateway("nginx", listener("foo-http", 80, gatewayv1.HTTPProtocolType, "foo.com")), route("foo", "foo.com", "foo-http")),
+			},
+			expectedListeners:    []gatewayv1.Listener{listener("foo-http", 80, gatewayv1.HTTPProtocolType, "foo.com")},
+			expectedSectionNames: map[string]string{"foo": "foo-http"},
+		},
+		{
+			name: "listeners identical but for their names are merged",
+			gatewayResources: []GatewayResources{
+				resources("a", gateway("nginx", listener("foo-http", 80, gatewayv1.HTTPProtocolType, "foo.com")), route("foo", "foo.com", "foo-http")),
+				resources("b", gateway("nginx", listener("foo-com-http", 80, gatewayv1.HTTPProtocolType, "foo.com")), route("other-foo", "foo.com", "foo-com-http")),
+			},
+			expectedListeners:    []gatewayv1.Listener{listener("foo-http", 80, gatewayv1.HTTPProtocolType, "foo.com")},
+			expectedSectionNames: map[string]string{"foo": "foo-http", "other-foo": "foo-http"},
+		},
+		{
+			name: "distinct listeners are combined",
+			gatewayResources: []GatewayResources{
+				resources("a", gateway("nginx", listener("foo-https", 443, gatewayv1.HTTPSProtocolType, "foo.com"))),
+				resources("b", gateway("nginx", listener("bar-tls", 443, gatewayv1.TLSProtocolType, "bar.com"), listener("foo-https", 443, gatewayv1.HTTPSProtocolType, "foo.com"))),
+			},
+			expectedListeners: []gatewayv1.Listener{
+				listener("foo-https", 443, gatewayv1.HTTPSProtocolType, "foo.com"),
+				listener("bar-tls", 443, gatewayv1.TLSProtocolType, "bar.com"),
+			},
+		},
+		{
+			name: "listeners with the same name are renamed",
+			gatewayResources: []GatewayResources{
+				resources("a", gateway("nginx", listener("http", 80, gatewayv1.HTTPProtocolType, "foo.com")), route("foo", "foo.com", "http")),
+				resources("b", gateway("nginx", listener("http", 80, gatewayv1.HTTPProtocolType, "bar.com")), route("bar", "bar.com", "http")),
+			},
+			expectedListeners: []gatewayv1.Listener{
+				listener("http", 80, gatewayv1.HTTPProtocolType, "foo.com"),
+				listener("http-2", 80, gatewayv1.HTTPProtocolType, "bar.com"),
+			},
+			expectedSectionNames: map[string]string{"foo": "http", "bar": "http-2"},
+		},
+		{
+			name: "listeners with incompatible protocols on the same port conflict",
+			gatewayResources: []GatewayResources{
+				resources("a", gateway("nginx", listener("foo-http", 8443, gatewayv1.HTTPProtocolType, "foo.com"))),
+				resources("b", gateway("nginx", listener("bar-https", 8443, gatewayv1.HTTPSProtocolType, "bar.com"))),
+			},
+			expectedErrors: []string{
+				`listener "foo-http" from ingress-nginx (networking.k8s.io/v1/Ingress/default/a) conflicts with listener "bar-https" from kong (networking.k8s.io/v1/Ingress/default/b): protocols HTTP and HTTPS cannot share port 8443`,
+			},
+		},
+		{
+			name: "listeners with the same port, protocol and hostname conflict",
+			gatewayResources: []GatewayResources{
+				resources("a", gateway("nginx", withCertificate(listener("foo-https", 443, gatewayv1.HTTPSProtocolType, "foo.com"), "foo-cert"))),
+				resources("b", gateway("nginx", withCertificate(listener("foo-https", 443, gatewayv1.HTTPSProtocolType, "foo.com"), "other-cert"))),
+			},
+			expectedErrors: []string{
+				`listener "foo-https" from ingress-nginx (networking.k8s.io/v1/Ingress/default/a) conflicts with listener "foo-https" from kong (networking.k8s.io/v1/Ingress/default/b): same port 443, protocol HTTPS and hostname foo.com`,
+			},
+		},
+		{
+			name: "gateways with different classes conflict",
+			gatewayResources: []GatewayResources{
+				resources("a", gateway("nginx")),
+				resources("b", gateway("kong")),
+			},
+			expectedErrors: []string{
+				`conflicting gatewayClassName "nginx" from ingress-nginx (networking.k8s.io/v1/Ingress/default/a) and "kong" from kong (networking.k8s.io/v1/Ingress/default/b)`,
+			},
+		},
+		{
+			name: "routes with different definitions conflict",
+			gatewayResources: []GatewayResources{
+				resources("a", gateway("nginx"), route("web", "foo.com", "foo-http")),
+				resources("b", gateway("nginx"), route("web", "bar.com", "bar-http")),
+			},
+			expectedErrors: []string{
+				`conflicting HTTPRoute definitions from ingress-nginx (networking.k8s.io/v1/Ingress/default/a) and from kong (networking.k8s.io/v1/Ingress/default/b)`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			merged, errs := mergeGatewayResources([]string{"ingress-nginx", "kong"}, tc.gatewayResources)
+			if len(errs) != len(tc.expectedErrors) {
+				t.Fatalf("Expected %d errors, got %d: %v", len(tc.expectedErrors), len(errs), errs)
+			}
+			for i, expected := range tc.expectedErrors {
+				if !strings.Contains(errs[i].Error(), expected) {
+					t.Errorf("Expected error %d to contain %q, got %q", i, expected, errs[i].Error())
+				}
+			}
+			if len(tc.expectedErrors) > 0 {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expectedListeners, merged.Gateways[gatewayKey].Spec.Listeners); diff != "" {
+				t.Errorf("Unexpected listeners, diff (-want +got):\n%s", diff)
+			}
+			if len(merged.HTTPRoutes) != len(tc.expectedSectionNames) {
+				t.Errorf("Expected %d HTTPRoutes, got %d", len(tc.expectedSectionNames), len(merged.HTTPRoutes))
+			}
+			for name, sectionName := range tc.expectedSectionNames {
+				httpRoute := merged.HTTPRoutes[types.NamespacedName{Namespace: gatewayKey.Namespace, Name: name}]
+				if len(httpRoute.Spec.ParentRefs) != 1 || string(*httpRoute.Spec.ParentRefs[0].SectionName) != sectionName {
+					t.Errorf("Expected HTTPRoute %s to attach to listener %s, got %v", name, sectionName, httpRoute.Spec.ParentRefs)
+				}
+			}
+		})
+	}
+}
+
+func Test_mergeGatewayResourcesTLSSecretReferenceGrants(t *testing.T) {
+	grants := func(gatewayNamespace string, secrets ...types.NamespacedName) GatewayResources {
+		gr := GatewayResources{ReferenceGrants: map[types.NamespacedName]gatewayv1beta1.ReferenceGrant{}}
+		for _, secret := range secrets {
+			GrantTLSSecretReference(gr.ReferenceGrants, gatewayNamespace, secret)
+		}
+		return gr
+	}
+
+	merged, errs := MergeGatewayResources(
+		grants("gateway-system", types.NamespacedName{Namespace: "team-a", Name: "web-cert"}),
+		grants("gateway-system", types.NamespacedName{Namespace: "team-a", Name: "api-cert"}, types.NamespacedName{Namespace: "team-a", Name: "web-cert"}),
+		grants("internal", types.NamespacedName{Namespace: "team-a", Name: "api-cert"}),
+	)
+	if len(errs) > 0 {
+		t.Fatalf("Expected no errors, got %v", errs)
+	}
+
+	expectedSpecs := map[types.NamespacedName]gatewayv1beta1.ReferenceGrantSpec{
+		{Namespace: "team-a", Name: TLSSecretsReferenceGrantName}: {
+			From: []gatewayv1beta1.ReferenceGrantFrom{
+				{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: "gateway-system"},
+				{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: "internal"},
+			},
+			To: []gatewayv1beta1.ReferenceGrantTo{
+				{Kind: "Secret", Name: ptr.To[gatewayv1.ObjectName]("web-cert")},
+				{Kind: "Secret", Name: ptr.To[gatewayv1.ObjectName]("api-cert")},
+			},
+		},
+	}
+	specs := map[types.NamespacedName]gatewayv1beta1.ReferenceGrantSpec{}
+	for nn, grant := range merged.ReferenceGrants {
+		specs[nn] = grant.Spec
+	}
+	if diff := cmp.Diff(expectedSpecs, specs); diff != "" {
+		t.Errorf("Unexpected ReferenceGrants, diff (-want +got):\n%s", diff)
+	}
+}
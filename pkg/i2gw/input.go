@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Input is a source the providers read their resources from, instead of the
+// cluster. Every input is read on its own, so that providers reading
+// documents other than Kubernetes manifests, such as OpenAPI specs, get them
+// as they are.
+type Input struct {
+	// Name identifies the input in error messages, e.g. the path of the file
+	// it was read from.
+	Name string
+
+	// Data is the content of the input, usually YAML or JSON manifests.
+	Data []byte
+
+	// Objects, when not nil, are the resources of the input, already
+	// decoded. Data is ignored then.
+	Objects []*unstructured.Unstructured
+}
+
+// readInputs returns the inputs described by the options, or nil when the
+// resources are read from a cluster.
+func readInputs(opts Options) ([]Input, error) {
+	switch {
+	case opts.Inputs != nil:
+		return opts.Inputs, nil
+	case opts.InputFile != "":
+		data, err := os.ReadFile(opts.InputFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %v: %w", opts.InputFile, err)
+		}
+		return []Input{{Name: opts.InputFile, Data: data}}, nil
+	case opts.Objects != nil:
+		objects := make([]*unstructured.Unstructured, 0, len(opts.Objects))
+		for i, obj := range opts.Objects {
+			if obj.GetObjectKind().GroupVersionKind().Kind == "" {
+				return nil, fmt.Errorf("object %d has no kind set", i)
+			}
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert object %d: %w", i, err)
+			}
+			objects = append(objects, &unstructured.Unstructured{Object: content})
+		}
+		return []Input{{Name: "objects", Objects: objects}}, nil
+	case opts.Reader != nil:
+		data, err := io.ReadAll(opts.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the input: %w", err)
+		}
+		return []Input{{Name: "reader", Data: data}}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Format is an output format of the notifications.
+type Format string
+
+const (
+	TableFormat Format = "table"
+	JSONFormat  Format = "json"
+	SARIFFormat Format = "sarif"
+)
+
+// SupportedFormats are the formats the notifications can be written in.
+var SupportedFormats = []Format{TableFormat, JSONFormat, SARIFFormat}
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "ingress2gateway"
+	toolURI      = "https://github.com/kubernetes-sigs/ingress2gateway"
+)
+
+// NotificationReport is the machine-readable representation of a Notification.
+type NotificationReport struct {
+	Type           MessageType       `json:"type"`
+	Provider       string            `json:"provider"`
+	Message        string            `json:"message"`
+	FieldPath      string            `json:"fieldPath,omitempty"`
+	CallingObjects []ObjectReference `json:"callingObjects,omitempty"`
+}
+
+// ObjectReference identifies a calling object of a notification.
+type ObjectReference struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func (r ObjectReference) String() string {
+	if r.Namespace == "" {
+		return r.Kind + "/" + r.Name
+	}
+	return r.Kind + "/" + r.Namespace + "/" + r.Name
+}
+
+// Reports returns the notifications as NotificationReports, sorted by provider
+// and in the order they were dispatched.
+func (na *NotificationAggregator) Reports() []NotificationReport {
+	na.mutex.Lock()
+	defer na.mutex.Unlock()
+
+	reports := []NotificationReport{}
+	for _, provider := range na.sortedProviders() {
+		for _, n := range na.Notifications[provider] {
+			report := NotificationReport{
+				Type:           n.Type,
+				Provider:       provider,
+				Message:        n.Message,
+				CallingObjects: objectReferences(n.CallingObjects),
+			}
+			if n.FieldPath != nil {
+				report.FieldPath = n.FieldPath.String()
+			}
+			reports = append(reports, report)
+		}
+	}
+	return reports
+}
+
+func objectReferences(objects []client.Object) []ObjectReference {
+	var refs []ObjectReference
+	for _, o := range objects {
+		refs = append(refs, ObjectReference{
+			Kind:      o.GetObjectKind().GroupVersionKind().Kind,
+			Namespace: o.GetNamespace(),
+			Name:      o.GetName(),
+		})
+	}
+	return refs
+}
+
+// WriteNotifications writes all the notifications to out in the given format.
+func (na *NotificationAggregator) WriteNotifications(out io.Writer, format Format) error {
+	switch format {
+	case TableFormat:
+		tables := na.CreateNotificationTables()
+		providers := make([]string, 0, len(tables))
+		for provider := range tables {
+			providers = append(providers, provider)
+		}
+		slices.Sort(providers)
+		for _, provider := range providers {
+			if _, err := fmt.Fprintln(out, tables[provider]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case JSONFormat:
+		return writeJSON(out, na.Reports())
+	case SARIFFormat:
+		return writeJSON(out, toSARIF(na.Reports()))
+	default:
+		return fmt.Errorf("unsupported notifications format %q, supported formats are %v", format, SupportedFormats)
+	}
+}
+
+func writeJSON(out io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// The subset of the SARIF 2.1.0 format used to report notifications. Every
+// provider is a rule, and every notification a result whose logical locations
+// are the calling objects.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+func toSARIF(reports []NotificationReport) sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{Name: toolName, InformationURI: toolURI}},
+		// Results must be an empty array rather than null when there are none.
+		Results: []sarifResult{},
+	}
+
+	for _, report := range reports {
+		if !slices.Contains(run.Tool.Driver.Rules, sarifRule{ID: report.Provider}) {
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: report.Provider})
+		}
+
+		result := sarifResult{
+			RuleID:     report.Provider,
+			Level:      sarifLevel(report.Type),
+			Message:    sarifMessage{Text: report.Message},
+			Properties: map[string]string{"type": string(report.Type)},
+		}
+		if report.FieldPath != "" {
+			result.Properties["fieldPath"] = report.FieldPath
+		}
+		for _, ref := range report.CallingObjects {
+			name := ref.Name
+			if ref.Namespace != "" {
+				name = ref.Namespace + "/" + ref.Name
+			}
+			result.Locations = append(result.Locations, sarifLocation{
+				LogicalLocations: []sarifLogicalLocation{{Name: name, FullyQualifiedName: ref.String(), Kind: "resource"}},
+			})
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	return sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}}
+}
+
+func sarifLevel(mType MessageType) string {
+	switch mType {
+	case ErrorNotification:
+		return "error"
+	case WarningNotification:
+		return "warning"
+	default:
+		return "note"
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func testAggregator() *NotificationAggregator {
+	ingress := &networkingv1.Ingress{
+		TypeMeta:   metav1.TypeMeta{Kind: "Ingress"},
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+	}
+	return &NotificationAggregator{
+		Notifications: map[string][]Notification{
+			"kong": {
+				NewNotification(InfoNotification, "info from kong"),
+			},
+			"ingress-nginx": {
+				NewFieldNotification(WarningNotification, field.NewPath("spec", "rules").Index(0), "warning from ingress-nginx", ingress),
+				NewNotification(ErrorNotification, "error from ingress-nginx", ingress),
+			},
+		},
+	}
+}
+
+func TestReports(t *testing.T) {
+	want := []NotificationReport{
+		{
+			Type:           WarningNotification,
+			Provider:       "ingress-nginx",
+			Message:        "warning from ingress-nginx",
+			FieldPath:      "spec.rules[0]",
+			CallingObjects: []ObjectReference{{Kind: "Ingress", Namespace: "default", Name: "web"}},
+		},
+		{
+			Type:           ErrorNotification,
+			Provider:       "ingress-nginx",
+			Message:        "error from ingress-nginx",
+			CallingObjects: []ObjectReference{{Kind: "Ingress", Namespace: "default", Name: "web"}},
+		},
+		{
+			Type:     InfoNotification,
+			Provider: "kong",
+			Message:  "info from kong",
+		},
+	}
+	assert.Equal(t, want, testAggregator().Reports())
+}
+
+func TestWriteNotificationsJSON(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, testAggregator().WriteNotifications(&out, JSONFormat))
+
+	var reports []NotificationReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &reports))
+	assert.Equal(t, testAggregator().Reports(), reports)
+}
+
+func TestWriteNotificationsSARIF(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, testAggregator().WriteNotifications(&out, SARIFFormat))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+
+	assert.Equal(t, []sarifRule{{ID: "ingress-nginx"}, {ID: "kong"}}, run.Tool.Driver.Rules)
+	require.Len(t, run.Results, 3)
+	assert.Equal(t, sarifResult{
+		RuleID:  "ingress-nginx",
+		Level:   "warning",
+		Message: sarifMessage{Text: "warning from ingress-nginx"},
+		Locations: []sarifLocation{{
+			LogicalLocations: []sarifLogicalLocation{{Name: "default/web", FullyQualifiedName: "Ingress/default/web", Kind: "resource"}},
+		}},
+		Properties: map[string]string{"type": "WARNING", "fieldPath": "spec.rules[0]"},
+	}, run.Results[0])
+	assert.Equal(t, "error", run.Results[1].Level)
+	assert.Equal(t, "note", run.Results[2].Level)
+}
+
+func TestWriteNotificationsEmpty(t *testing.T) {
+	na := NotificationAggregator{Notifications: map[string][]Notification{}}
+	for _, format := range []Format{JSONFormat, SARIFFormat} {
+		var out bytes.Buffer
+		require.NoError(t, na.WriteNotifications(&out, format))
+		assert.NotContains(t, out.String(), "null", "format %s", format)
+	}
+	assert.Error(t, na.WriteNotifications(&bytes.Buffer{}, Format("xml")))
+}
@@ -17,19 +17,18 @@ limitations under the License.
 package notifications
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/olekukonko/tablewriter"
 )
 
-func init() {
-	NotificationAggr = NotificationAggregator{Notifications: map[string][]Notification{}}
-}
-
 const (
 	InfoNotification    MessageType = "INFO"
 	WarningNotification MessageType = "WARNING"
@@ -38,9 +37,36 @@ const (
 
 type MessageType string
 
+// MessageTypes are the message types, ordered by increasing severity.
+var MessageTypes = []MessageType{InfoNotification, WarningNotification, ErrorNotification}
+
+// ParseMessageType returns the MessageType named s, case-insensitively.
+func ParseMessageType(s string) (MessageType, error) {
+	for _, mType := range MessageTypes {
+		if strings.EqualFold(s, string(mType)) {
+			return mType, nil
+		}
+	}
+	return "", fmt.Errorf("unknown message type %q, supported types are %v", s, MessageTypes)
+}
+
+// Severity returns the severity of the message type, higher is more severe.
+// Unknown message types have a severity of -1.
+func (t MessageType) Severity() int {
+	return slices.Index(MessageTypes, t)
+}
+
+// AtLeast returns whether the message type is at least as severe as other.
+func (t MessageType) AtLeast(other MessageType) bool {
+	return t.Severity() >= other.Severity()
+}
+
 type Notification struct {
-	Type           MessageType
-	Message        string
+	Type    MessageType
+	Message string
+	// FieldPath is the path of the field of the calling objects the
+	// notification is about, if any.
+	FieldPath      *field.Path
 	CallingObjects []client.Object
 }
 
@@ -49,7 +75,28 @@ type NotificationAggregator struct {
 	Notifications map[string][]Notification
 }
 
-var NotificationAggr NotificationAggregator
+// NewNotificationAggregator returns an empty NotificationAggregator, to collect
+// the notifications of a single conversion run.
+func NewNotificationAggregator() *NotificationAggregator {
+	return &NotificationAggregator{Notifications: map[string][]Notification{}}
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the aggregator.
+func NewContext(ctx context.Context, aggregator *NotificationAggregator) context.Context {
+	return context.WithValue(ctx, contextKey{}, aggregator)
+}
+
+// FromContext returns the aggregator carried by ctx. It panics if there is
+// none, as the notifications would be lost.
+func FromContext(ctx context.Context) *NotificationAggregator {
+	aggregator, _ := ctx.Value(contextKey{}).(*NotificationAggregator)
+	if aggregator == nil {
+		panic("notifications: no NotificationAggregator in the context")
+	}
+	return aggregator
+}
 
 // DispatchNotification is used to send a notification to the NotificationAggregator
 func (na *NotificationAggregator) DispatchNotification(notification Notification, ProviderName string) {
@@ -58,12 +105,50 @@ func (na *NotificationAggregator) DispatchNotification(notification Notification
 	na.mutex.Unlock()
 }
 
+// Reset removes all the notifications.
+func (na *NotificationAggregator) Reset() {
+	na.mutex.Lock()
+	defer na.mutex.Unlock()
+	na.Notifications = map[string][]Notification{}
+}
+
+// Snapshot returns a copy of the notifications by provider.
+func (na *NotificationAggregator) Snapshot() map[string][]Notification {
+	na.mutex.Lock()
+	defer na.mutex.Unlock()
+	snapshot := make(map[string][]Notification, len(na.Notifications))
+	for provider, msgs := range na.Notifications {
+		snapshot[provider] = slices.Clone(msgs)
+	}
+	return snapshot
+}
+
+// CountAtLeast returns the number of notifications at least as severe as mType.
+func (na *NotificationAggregator) CountAtLeast(mType MessageType) int {
+	na.mutex.Lock()
+	defer na.mutex.Unlock()
+
+	count := 0
+	for _, msgs := range na.Notifications {
+		for _, n := range msgs {
+			if n.Type.AtLeast(mType) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
 // CreateNotificationTables takes all generated notifications and returns a map[string]string
 // that displays the notifications in a tabular format based on provider
 func (na *NotificationAggregator) CreateNotificationTables() map[string]string {
+	na.mutex.Lock()
+	defer na.mutex.Unlock()
+
 	notificationTablesMap := make(map[string]string)
 
-	for provider, msgs := range na.Notifications {
+	for _, provider := range na.sortedProviders() {
+		msgs := na.Notifications[provider]
 		providerTable := strings.Builder{}
 
 		t := tablewriter.NewWriter(&providerTable)
@@ -84,6 +169,17 @@ func (na *NotificationAggregator) CreateNotificationTables() map[string]string {
 	return notificationTablesMap
 }
 
+// sortedProviders returns the providers that dispatched notifications, in
+// lexical order. The caller must hold the mutex.
+func (na *NotificationAggregator) sortedProviders() []string {
+	providers := make([]string, 0, len(na.Notifications))
+	for provider := range na.Notifications {
+		providers = append(providers, provider)
+	}
+	slices.Sort(providers)
+	return providers
+}
+
 func convertObjectsToStr(ob []client.Object) string {
 	var sb strings.Builder
 
@@ -101,3 +197,9 @@ func convertObjectsToStr(ob []client.Object) string {
 func NewNotification(mType MessageType, message string, callingObject ...client.Object) Notification {
 	return Notification{Type: mType, Message: message, CallingObjects: callingObject}
 }
+
+// NewFieldNotification returns a notification about the field at fieldPath of
+// the calling objects.
+func NewFieldNotification(mType MessageType, fieldPath *field.Path, message string, callingObject ...client.Object) Notification {
+	return Notification{Type: mType, Message: message, FieldPath: fieldPath, CallingObjects: callingObject}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package notifications
 
 import (
+	"context"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -153,6 +155,28 @@ func TestCreateNotificationsTables(t *testing.T) {
 	}
 }
 
+func TestCreateNotificationTablesWhileDispatching(t *testing.T) {
+	na := NewNotificationAggregator()
+
+	var wg sync.WaitGroup
+	for _, provider := range []string{"istio", "kong"} {
+		provider := provider
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				na.DispatchNotification(NewNotification(InfoNotification, "converted"), provider)
+			}
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		na.CreateNotificationTables()
+	}
+	wg.Wait()
+
+	assert.Len(t, na.CreateNotificationTables(), 2)
+}
+
 func TestConvertObjectsToStr(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -208,3 +232,51 @@ func TestConvertObjectsToStr(t *testing.T) {
 		})
 	}
 }
+
+func TestMessageTypeSeverity(t *testing.T) {
+	assert.True(t, ErrorNotification.AtLeast(WarningNotification))
+	assert.True(t, WarningNotification.AtLeast(WarningNotification))
+	assert.False(t, InfoNotification.AtLeast(WarningNotification))
+	assert.False(t, WarningNotification.AtLeast(ErrorNotification))
+
+	mType, err := ParseMessageType("warning")
+	assert.NoError(t, err)
+	assert.Equal(t, WarningNotification, mType)
+	_, err = ParseMessageType("fatal")
+	assert.Error(t, err)
+}
+
+func TestCountAtLeast(t *testing.T) {
+	na := NotificationAggregator{
+		Notifications: map[string][]Notification{
+			"istio": {
+				NewNotification(InfoNotification, "info"),
+				NewNotification(WarningNotification, "warning"),
+			},
+			"kong": {
+				NewNotification(ErrorNotification, "error"),
+			},
+		},
+	}
+	assert.Equal(t, 3, na.CountAtLeast(InfoNotification))
+	assert.Equal(t, 2, na.CountAtLeast(WarningNotification))
+	assert.Equal(t, 1, na.CountAtLeast(ErrorNotification))
+}
+
+func TestResetAndSnapshot(t *testing.T) {
+	na := NewNotificationAggregator()
+	na.DispatchNotification(NewNotification(WarningNotification, "first run"), "istio")
+
+	snapshot := na.Snapshot()
+	na.Reset()
+	na.DispatchNotification(NewNotification(InfoNotification, "second run"), "kong")
+
+	assert.Equal(t, map[string][]Notification{"istio": {NewNotification(WarningNotification, "first run")}}, snapshot)
+	assert.Equal(t, map[string][]Notification{"kong": {NewNotification(InfoNotification, "second run")}}, na.Snapshot())
+}
+
+func TestFromContext(t *testing.T) {
+	na := NewNotificationAggregator()
+	assert.Same(t, na, FromContext(NewContext(context.Background(), na)))
+	assert.Panics(t, func() { FromContext(context.Background()) })
+}
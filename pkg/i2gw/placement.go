@@ -0,0 +1,333 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// TLSSecretsReferenceGrantName is the name of the ReferenceGrants allowing
+// Gateways to reference the TLS Secrets of another namespace, see
+// GrantTLSSecretReference.
+const TLSSecretsReferenceGrantName = "from-gateways-to-tls-secrets"
+
+// GatewayPlacement describes where the generated Gateways are placed.
+type GatewayPlacement struct {
+	// ClassMapping maps the classes of the converted resources to the
+	// GatewayClass and Gateway they are converted to.
+	ClassMapping ClassMapping
+
+	// Namespace, when set, is the namespace of a single Gateway per class,
+	// shared by the routes of all namespaces, instead of a Gateway per class
+	// and namespace. It applies to the Gateways generated from classed
+	// resources, e.g. Ingresses, which the ClassMapping doesn't give a
+	// namespace.
+	Namespace string
+
+	// RouteNamespaceSelector, when set, selects the namespaces whose routes
+	// may attach to the Gateways moved to another namespace. The routes of
+	// all namespaces may attach when nil.
+	RouteNamespaceSelector *metav1.LabelSelector
+}
+
+// PlaceGateways moves the Gateways as described by the placement:
+//   - the Gateways of a mapped class get its GatewayClass and, when set, its
+//     Gateway name and namespace;
+//   - the other Gateways generated from classed resources are moved to the
+//     shared namespace, if any.
+//
+// Gateways moved to the same Gateway are merged as MergeGatewayResources
+// does, and the parentRefs of the routes are rewritten to reference the moved
+// Gateways and listeners, with their namespace. The listeners of a Gateway
+// moved to another namespace allow the routes of the selected namespaces to
+// attach, and its certificateRefs keep referencing the Secrets of its former
+// namespace, which grant it access with a ReferenceGrant. A GatewayClass is
+// generated for every mapped class with a controllerName.
+func PlaceGateways(gatewayResources *GatewayResources, placement GatewayPlacement) field.ErrorList {
+	if len(placement.ClassMapping) == 0 && placement.Namespace == "" {
+		return nil
+	}
+
+	errs := addMappedGatewayClasses(gatewayResources, placement.ClassMapping)
+
+	// The Gateways are merged again, from scratch, as several of them may be
+	// moved to the same Gateway.
+	var (
+		gateways  = map[types.NamespacedName]gatewayv1.Gateway{}
+		sources   = Sources{}
+		classes   = map[types.NamespacedName]string{}
+		moves     = map[types.NamespacedName]types.NamespacedName{}
+		renames   = listenerRenames{}
+		origins   = newMergeOrigins()
+		relocated = map[types.NamespacedName]bool{}
+	)
+	for _, nn := range sortedKeys(gatewayResources.Gateways) {
+		g := gatewayResources.Gateways[nn]
+		ref := ObjectRefFor(&g)
+
+		to := placeGateway(nn, &g, gatewayResources.SourceClasses, placement)
+		if to.Namespace != nn.Namespace {
+			pinCertificateRefNamespaces(&g, nn.Namespace)
+			relocated[to] = true
+		}
+		g.Namespace, g.Name = to.Namespace, to.Name
+		// Unmoved Gateways are recorded too, as their listeners may be
+		// renamed when another Gateway is moved to the same Gateway.
+		moves[nn] = to
+
+		originOf := func(client.Object) string {
+			return describeOrigin("", gatewayResources.Sources[ref])
+		}
+		gatewayRenames, gatewayErrs := mergeGateways(gateways, map[types.NamespacedName]gatewayv1.Gateway{to: g}, originOf, origins)
+		errs = append(errs, gatewayErrs...)
+		for from, name := range gatewayRenames[to] {
+			renames.add(nn, from, name)
+		}
+
+		sources.Add(ObjectRefFor(&g), gatewayResources.Sources[ref]...)
+		delete(gatewayResources.Sources, ref)
+		if sourceClass, ok := gatewayResources.SourceClasses[nn]; ok {
+			classes[to] = sourceClass
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	gatewayResources.Gateways = gateways
+	gatewayResources.SourceClasses = classes
+	if gatewayResources.Sources == nil {
+		gatewayResources.Sources = Sources{}
+	}
+	for obj, objSources := range sources {
+		gatewayResources.Sources.Add(obj, objSources...)
+	}
+
+	gatewayResources.HTTPRoutes = moveParentRefs(gatewayResources.HTTPRoutes, func(r *gatewayv1.HTTPRoute) *gatewayv1.CommonRouteSpec { return &r.Spec.CommonRouteSpec }, moves, renames)
+	gatewayResources.TLSRoutes = moveParentRefs(gatewayResources.TLSRoutes, func(r *gatewayv1alpha2.TLSRoute) *gatewayv1.CommonRouteSpec { return &r.Spec.CommonRouteSpec }, moves, renames)
+	gatewayResources.TCPRoutes = moveParentRefs(gatewayResources.TCPRoutes, func(r *gatewayv1alpha2.TCPRoute) *gatewayv1.CommonRouteSpec { return &r.Spec.CommonRouteSpec }, moves, renames)
+	gatewayResources.UDPRoutes = moveParentRefs(gatewayResources.UDPRoutes, func(r *gatewayv1alpha2.UDPRoute) *gatewayv1.CommonRouteSpec { return &r.Spec.CommonRouteSpec }, moves, renames)
+
+	for _, nn := range sortedKeys(gatewayResources.Gateways) {
+		if !relocated[nn] {
+			continue
+		}
+		g := gatewayResources.Gateways[nn]
+		allowRouteNamespaces(&g, placement.RouteNamespaceSelector)
+		gatewayResources.Gateways[nn] = g
+	}
+	addTLSSecretReferenceGrants(gatewayResources, relocated)
+	return nil
+}
+
+// placeGateway returns where the Gateway is placed, and sets its GatewayClass
+// when its class is mapped.
+func placeGateway(nn types.NamespacedName, g *gatewayv1.Gateway, sourceClasses map[types.NamespacedName]string, placement GatewayPlacement) types.NamespacedName {
+	to := nn
+	sourceClass, classed := sourceClasses[nn]
+	target, mapped := placement.ClassMapping[sourceClass]
+	if !classed || !mapped {
+		target, mapped = placement.ClassMapping[string(g.Spec.GatewayClassName)]
+	}
+	if mapped {
+		g.Spec.GatewayClassName = gatewayv1.ObjectName(target.GatewayClassName)
+		if target.GatewayName != "" {
+			to.Name = target.GatewayName
+		}
+	}
+	switch {
+	case mapped && target.GatewayNamespace != "":
+		to.Namespace = target.GatewayNamespace
+	case classed && placement.Namespace != "":
+		to.Namespace = placement.Namespace
+	}
+	return to
+}
+
+// addMappedGatewayClasses adds a GatewayClass for every mapped class with a
+// controllerName.
+func addMappedGatewayClasses(gatewayResources *GatewayResources, mapping ClassMapping) field.ErrorList {
+	var errs field.ErrorList
+	for _, class := range sortedClasses(mapping) {
+		target := mapping[class]
+		if target.ControllerName == "" {
+			continue
+		}
+		gatewayClass := gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: target.GatewayClassName},
+			Spec:       gatewayv1.GatewayClassSpec{ControllerName: gatewayv1.GatewayController(target.ControllerName)},
+		}
+		gatewayClass.SetGroupVersionKind(gatewayv1.SchemeGroupVersion.WithKind("GatewayClass"))
+		nn := types.NamespacedName{Name: target.GatewayClassName}
+		if existing, ok := gatewayResources.GatewayClasses[nn]; ok && existing.Spec.ControllerName != gatewayClass.Spec.ControllerName {
+			errs = append(errs, field.Invalid(field.NewPath(nn.Name).Child("spec", "controllerName"), target.ControllerName,
+				fmt.Sprintf("conflicting controllerName %q and %q for GatewayClass %s", existing.Spec.ControllerName, target.ControllerName, nn.Name)))
+			continue
+		}
+		if gatewayResources.GatewayClasses == nil {
+			gatewayResources.GatewayClasses = map[types.NamespacedName]gatewayv1.GatewayClass{}
+		}
+		gatewayResources.GatewayClasses[nn] = gatewayClass
+	}
+	return errs
+}
+
+// pinCertificateRefNamespaces sets the namespace of the certificateRefs of
+// the Gateway without one, which reference the Secrets of the namespace the
+// Gateway is moved out of.
+func pinCertificateRefNamespaces(g *gatewayv1.Gateway, namespace string) {
+	// The listeners are copied before being modified, as they may share their
+	// backing arrays with other Gateways.
+	g.Spec.Listeners = slices.Clone(g.Spec.Listeners)
+	for i, listener := range g.Spec.Listeners {
+		if listener.TLS == nil {
+			continue
+		}
+		tls := listener.TLS.DeepCopy()
+		for j, certificateRef := range tls.CertificateRefs {
+			if certificateRef.Namespace == nil {
+				tls.CertificateRefs[j].Namespace = ptr.To(gatewayv1.Namespace(namespace))
+			}
+		}
+		g.Spec.Listeners[i].TLS = tls
+	}
+}
+
+// allowRouteNamespaces allows the routes of the namespaces selected by the
+// selector, or of all namespaces when nil, to attach to the listeners of the
+// Gateway.
+func allowRouteNamespaces(g *gatewayv1.Gateway, selector *metav1.LabelSelector) {
+	namespaces := &gatewayv1.RouteNamespaces{From: ptr.To(gatewayv1.NamespacesFromAll)}
+	if selector != nil {
+		namespaces = &gatewayv1.RouteNamespaces{From: ptr.To(gatewayv1.NamespacesFromSelector), Selector: selector.DeepCopy()}
+	}
+	g.Spec.Listeners = slices.Clone(g.Spec.Listeners)
+	for i := range g.Spec.Listeners {
+		allowedRoutes := &gatewayv1.AllowedRoutes{}
+		if g.Spec.Listeners[i].AllowedRoutes != nil {
+			allowedRoutes = g.Spec.Listeners[i].AllowedRoutes.DeepCopy()
+		}
+		allowedRoutes.Namespaces = namespaces.DeepCopy()
+		g.Spec.Listeners[i].AllowedRoutes = allowedRoutes
+	}
+}
+
+// addTLSSecretReferenceGrants grants the given Gateways access to the Secrets
+// referenced by their certificateRefs from other namespaces, see
+// GrantTLSSecretReference.
+func addTLSSecretReferenceGrants(gatewayResources *GatewayResources, gateways map[types.NamespacedName]bool) {
+	for _, nn := range sortedKeys(gatewayResources.Gateways) {
+		if !gateways[nn] {
+			continue
+		}
+		g := gatewayResources.Gateways[nn]
+		for _, listener := range g.Spec.Listeners {
+			if listener.TLS == nil {
+				continue
+			}
+			for _, certificateRef := range listener.TLS.CertificateRefs {
+				if certificateRef.Namespace == nil || string(*certificateRef.Namespace) == nn.Namespace ||
+					ptr.Deref(certificateRef.Group, "") != "" || ptr.Deref(certificateRef.Kind, "Secret") != "Secret" {
+					continue
+				}
+				if gatewayResources.ReferenceGrants == nil {
+					gatewayResources.ReferenceGrants = map[types.NamespacedName]gatewayv1beta1.ReferenceGrant{}
+				}
+				secret := types.NamespacedName{Namespace: string(*certificateRef.Namespace), Name: string(certificateRef.Name)}
+				grantKey := GrantTLSSecretReference(gatewayResources.ReferenceGrants, nn.Namespace, secret)
+				grant := gatewayResources.ReferenceGrants[grantKey]
+				gatewayResources.AddSources(&grant, gatewayResources.Sources[ObjectRefFor(&g)]...)
+			}
+		}
+	}
+}
+
+// GrantTLSSecretReference allows the Gateways of gatewayNamespace to reference
+// the Secret, with the ReferenceGrant named TLSSecretsReferenceGrantName in
+// the namespace of the Secret, which is added to grants if missing. There is
+// a single such ReferenceGrant per Secret namespace, granting access to all
+// its referenced Secrets. The key of the ReferenceGrant is returned.
+func GrantTLSSecretReference(grants map[types.NamespacedName]gatewayv1beta1.ReferenceGrant, gatewayNamespace string, secret types.NamespacedName) types.NamespacedName {
+	grantKey := types.NamespacedName{Namespace: secret.Namespace, Name: TLSSecretsReferenceGrantName}
+	grant, ok := grants[grantKey]
+	if !ok {
+		grant = gatewayv1beta1.ReferenceGrant{ObjectMeta: metav1.ObjectMeta{Namespace: grantKey.Namespace, Name: grantKey.Name}}
+		grant.SetGroupVersionKind(gatewayv1beta1.SchemeGroupVersion.WithKind("ReferenceGrant"))
+	}
+
+	// The From and To entries are copied before being appended to, as they
+	// may share their backing arrays with other ReferenceGrants.
+	from := gatewayv1beta1.ReferenceGrantFrom{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: gatewayv1.Namespace(gatewayNamespace)}
+	if !slices.Contains(grant.Spec.From, from) {
+		grant.Spec.From = append(slices.Clone(grant.Spec.From), from)
+	}
+	granted := slices.ContainsFunc(grant.Spec.To, func(to gatewayv1beta1.ReferenceGrantTo) bool {
+		return to.Kind == "Secret" && (to.Name == nil || string(*to.Name) == secret.Name)
+	})
+	if !granted {
+		grant.Spec.To = append(slices.Clone(grant.Spec.To), gatewayv1beta1.ReferenceGrantTo{Group: "", Kind: "Secret", Name: ptr.To(gatewayv1.ObjectName(secret.Name))})
+	}
+	grants[grantKey] = grant
+	return grantKey
+}
+
+// moveParentRefs returns a copy of the routes, whose parentRefs reference the
+// Gateways they were moved to and the new names of the renamed listeners. The
+// namespace of a parentRef is set when the Gateway is not in the namespace of
+// the route.
+func moveParentRefs[T any, PT interface {
+	*T
+	client.Object
+}](routes map[types.NamespacedName]T, commonSpec func(PT) *gatewayv1.CommonRouteSpec, moves map[types.NamespacedName]types.NamespacedName, renames listenerRenames) map[types.NamespacedName]T {
+	moved := make(map[types.NamespacedName]T, len(routes))
+	for nn, route := range routes {
+		route := route
+		spec := commonSpec(&route)
+		spec.ParentRefs = slices.Clone(spec.ParentRefs)
+		for i, parentRef := range spec.ParentRefs {
+			gateway, ok := parentRefGateway(nn.Namespace, parentRef)
+			if !ok {
+				continue
+			}
+			to, ok := moves[gateway]
+			if !ok {
+				continue
+			}
+			spec.ParentRefs[i].Name = gatewayv1.ObjectName(to.Name)
+			if to.Namespace != nn.Namespace || parentRef.Namespace != nil {
+				spec.ParentRefs[i].Namespace = ptr.To(gatewayv1.Namespace(to.Namespace))
+			}
+			if parentRef.SectionName != nil {
+				if name, ok := renames[gateway][*parentRef.SectionName]; ok {
+					spec.ParentRefs[i].SectionName = ptr.To(name)
+				}
+			}
+		}
+		moved[nn] = route
+	}
+	return moved
+}
@@ -0,0 +1,329 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func Test_PlaceGateways(t *testing.T) {
+	gateway := func(namespace, name, className string, listeners ...gatewayv1.Listener) gatewayv1.Gateway {
+		return gatewayv1.Gateway{
+			TypeMeta:   metav1.TypeMeta{APIVersion: gatewayv1.GroupVersion.String(), Kind: "Gateway"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: gatewayv1.ObjectName(className), Listeners: listeners},
+		}
+	}
+	listener := func(name, hostname string) gatewayv1.Listener {
+		return gatewayv1.Listener{Name: gatewayv1.SectionName(name), Hostname: ptr.To(gatewayv1.Hostname(hostname)), Port: 80, Protocol: gatewayv1.HTTPProtocolType}
+	}
+	tlsListener := func(name, hostname, secretName string) gatewayv1.Listener {
+		return gatewayv1.Listener{
+			Name:     gatewayv1.SectionName(name),
+			Hostname: ptr.To(gatewayv1.Hostname(hostname)),
+			Port:     443,
+			Protocol: gatewayv1.HTTPSProtocolType,
+			TLS:      &gatewayv1.GatewayTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{{Name: gatewayv1.ObjectName(secretName)}}},
+		}
+	}
+	route := func(namespace, name, gatewayName, sectionName string) gatewayv1.HTTPRoute {
+		return gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(gatewayName), SectionName: ptr.To(gatewayv1.SectionName(sectionName))}},
+				},
+			},
+		}
+	}
+	gatewayResources := func(gateways []gatewayv1.Gateway, sourceClasses map[types.NamespacedName]string, routes ...gatewayv1.HTTPRoute) GatewayResources {
+		gatewayResources := GatewayResources{
+			Gateways:      map[types.NamespacedName]gatewayv1.Gateway{},
+			HTTPRoutes:    map[types.NamespacedName]gatewayv1.HTTPRoute{},
+			Sources:       Sources{},
+			SourceClasses: sourceClasses,
+		}
+		for _, g := range gateways {
+			g := g
+			gatewayResources.Gateways[types.NamespacedName{Namespace: g.Namespace, Name: g.Name}] = g
+			gatewayResources.AddSources(&g, ObjectRef{
+				GroupVersionKind: schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+				NamespacedName:   types.NamespacedName{Namespace: g.Namespace, Name: "web"},
+			})
+		}
+		for _, r := range routes {
+			gatewayResources.HTTPRoutes[types.NamespacedName{Namespace: r.Namespace, Name: r.Name}] = r
+		}
+		return gatewayResources
+	}
+
+	testCases := []struct {
+		name                   string
+		gatewayResources       GatewayResources
+		placement              GatewayPlacement
+		expectedGateways       map[string]string
+		expectedListeners      map[string][]string
+		expectedParentRefs     map[string]string
+		expectedGatewayClasses map[string]string
+		// expectedAllowedRoutes are the namespaces allowed by the listeners,
+		// by Gateway and listener name.
+		expectedAllowedRoutes map[string]string
+		// expectedCertificateRefs are the namespaced certificateRefs, by
+		// Gateway.
+		expectedCertificateRefs map[string][]string
+		// expectedReferenceGrants are the Gateway namespaces and the Secrets
+		// of every ReferenceGrant.
+		expectedReferenceGrants map[string][]string
+		expectingError          bool
+	}{
+		{
+			name: "GatewayClass set by ingress class",
+			gatewayResources: gatewayResources(
+				[]gatewayv1.Gateway{gateway("default", "gce", "gke-l7-global-external-managed", listener("foo-http", "foo.com"))},
+				map[types.NamespacedName]string{{Namespace: "default", Name: "gce"}: "gce"},
+				route("default", "foo", "gce", "foo-http"),
+			),
+			placement:          GatewayPlacement{ClassMapping: ClassMapping{"gce": {GatewayClassName: "envoy-gateway"}}},
+			expectedGateways:   map[string]string{"default/gce": "envoy-gateway"},
+			expectedListeners:  map[string][]string{"default/gce": {"foo-http"}},
+			expectedParentRefs: map[string]string{"default/foo": "/gce/foo-http"},
+		},
+		{
+			name: "GatewayClass set by GatewayClass name",
+			gatewayResources: gatewayResources(
+				[]gatewayv1.Gateway{gateway("default", "istio-gateway", "istio", listener("foo-http", "foo.com"))},
+				nil,
+			),
+			placement:         GatewayPlacement{ClassMapping: ClassMapping{"istio": {GatewayClassName: "envoy-gateway"}}},
+			expectedGateways:  map[string]string{"default/istio-gateway": "envoy-gateway"},
+			expectedListeners: map[string][]string{"default/istio-gateway": {"foo-http"}},
+		},
+		{
+			name: "Gateways moved to the same Gateway",
+			gatewayResources: gatewayResources(
+				[]gatewayv1.Gateway{
+					gateway("team-a", "nginx", "nginx", listener("foo-http", "foo.com")),
+					gateway("team-b", "nginx", "nginx", listener("foo-http", "foo.org")),
+					gateway("team-b", "internal", "internal", listener("bar-http", "bar.org")),
+				},
+				map[types.NamespacedName]string{
+					{Namespace: "team-a", Name: "nginx"}:    "nginx",
+					{Namespace: "team-b", Name: "nginx"}:    "nginx",
+					{Namespace: "team-b", Name: "internal"}: "internal",
+				},
+				route("team-a", "foo", "nginx", "foo-http"),
+				route("team-b", "foo", "nginx", "foo-http"),
+				route("team-b", "bar", "internal", "bar-http"),
+			),
+			placement: GatewayPlacement{ClassMapping: ClassMapping{"nginx": {
+				GatewayClassName: "envoy-gateway",
+				GatewayName:      "envoy",
+				GatewayNamespace: "envoy-gateway-system",
+				ControllerName:   "gateway.envoyproxy.io/gatewayclass-controller",
+			}}},
+			expectedGateways: map[string]string{
+				"envoy-gateway-system/envoy": "envoy-gateway",
+				"team-b/internal":            "internal",
+			},
+			expectedListeners: map[string][]string{
+				"envoy-gateway-system/envoy": {"foo-http", "foo-http-2"},
+				"team-b/internal":            {"bar-http"},
+			},
+			expectedParentRefs: map[string]string{
+				"team-a/foo": "envoy-gateway-system/envoy/foo-http",
+				"team-b/foo": "envoy-gateway-system/envoy/foo-http-2",
+				"team-b/bar": "/internal/bar-http",
+			},
+			expectedGatewayClasses: map[string]string{"envoy-gateway": "gateway.envoyproxy.io/gatewayclass-controller"},
+			expectedAllowedRoutes: map[string]string{
+				"envoy-gateway-system/envoy/foo-http":   "All",
+				"envoy-gateway-system/envoy/foo-http-2": "All",
+			},
+		},
+		{
+			name: "shared Gateway namespace",
+			gatewayResources: gatewayResources(
+				[]gatewayv1.Gateway{
+					gateway("team-a", "nginx", "nginx", tlsListener("foo-https", "foo.com", "foo-cert")),
+					gateway("team-b", "nginx", "nginx", tlsListener("bar-https", "bar.com", "bar-cert"), tlsListener("baz-https", "baz.com", "bar-cert")),
+					gateway("default", "istio-gateway", "istio", listener("foo-http", "foo.org")),
+				},
+				map[types.NamespacedName]string{
+					{Namespace: "team-a", Name: "nginx"}: "nginx",
+					{Namespace: "team-b", Name: "nginx"}: "nginx",
+				},
+				route("team-a", "foo", "nginx", "foo-https"),
+				route("team-b", "bar", "nginx", "bar-https"),
+			),
+			placement: GatewayPlacement{
+				Namespace:              "gateway-system",
+				RouteNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"gateway-access": "true"}},
+			},
+			expectedGateways: map[string]string{
+				"gateway-system/nginx":  "nginx",
+				"default/istio-gateway": "istio",
+			},
+			expectedListeners: map[string][]string{
+				"gateway-system/nginx":  {"foo-https", "bar-https", "baz-https"},
+				"default/istio-gateway": {"foo-http"},
+			},
+			expectedParentRefs: map[string]string{
+				"team-a/foo": "gateway-system/nginx/foo-https",
+				"team-b/bar": "gateway-system/nginx/bar-https",
+			},
+			expectedAllowedRoutes: map[string]string{
+				"gateway-system/nginx/foo-https": "Selector",
+				"gateway-system/nginx/bar-https": "Selector",
+				"gateway-system/nginx/baz-https": "Selector",
+			},
+			expectedCertificateRefs: map[string][]string{
+				"gateway-system/nginx": {"team-a/foo-cert", "team-b/bar-cert", "team-b/bar-cert"},
+			},
+			expectedReferenceGrants: map[string][]string{
+				"team-a/" + TLSSecretsReferenceGrantName: {"gateway-system", "foo-cert"},
+				"team-b/" + TLSSecretsReferenceGrantName: {"gateway-system", "bar-cert"},
+			},
+		},
+		{
+			name: "conflicting listeners of moved Gateways",
+			gatewayResources: gatewayResources(
+				[]gatewayv1.Gateway{
+					gateway("team-a", "nginx", "nginx", listener("foo-http", "foo.com")),
+					gateway("team-b", "nginx", "nginx", gatewayv1.Listener{Name: "foo-tcp", Port: 80, Protocol: gatewayv1.TCPProtocolType}),
+				},
+				nil,
+			),
+			placement:      GatewayPlacement{ClassMapping: ClassMapping{"nginx": {GatewayClassName: "envoy-gateway", GatewayNamespace: "gateways"}}},
+			expectingError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := PlaceGateways(&tc.gatewayResources, tc.placement)
+			if tc.expectingError {
+				if len(errs) == 0 {
+					t.Fatalf("Expected errors, got none")
+				}
+				return
+			}
+			if len(errs) > 0 {
+				t.Fatalf("Expected no errors, got %v", errs)
+			}
+
+			gateways := map[string]string{}
+			listeners := map[string][]string{}
+			for nn, g := range tc.gatewayResources.Gateways {
+				g := g
+				gateways[nn.String()] = string(g.Spec.GatewayClassName)
+				for _, l := range g.Spec.Listeners {
+					listeners[nn.String()] = append(listeners[nn.String()], string(l.Name))
+				}
+				if len(tc.gatewayResources.Sources[ObjectRefFor(&g)]) == 0 {
+					t.Errorf("Expected Gateway %s to have sources", nn)
+				}
+			}
+			if diff := cmp.Diff(tc.expectedGateways, gateways); diff != "" {
+				t.Errorf("Unexpected GatewayClass by Gateway, diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedListeners, listeners); diff != "" {
+				t.Errorf("Unexpected listeners by Gateway, diff (-want +got):\n%s", diff)
+			}
+
+			parentRefs := map[string]string{}
+			for nn, r := range tc.gatewayResources.HTTPRoutes {
+				for _, parentRef := range r.Spec.ParentRefs {
+					parentRefs[nn.String()] = fmt.Sprintf("%s/%s/%s", ptr.Deref(parentRef.Namespace, ""), parentRef.Name, ptr.Deref(parentRef.SectionName, ""))
+				}
+			}
+			if len(parentRefs) == 0 {
+				parentRefs = nil
+			}
+			if diff := cmp.Diff(tc.expectedParentRefs, parentRefs); diff != "" {
+				t.Errorf("Unexpected parentRefs by HTTPRoute, diff (-want +got):\n%s", diff)
+			}
+
+			gatewayClasses := map[string]string{}
+			for nn, gc := range tc.gatewayResources.GatewayClasses {
+				gatewayClasses[nn.Name] = string(gc.Spec.ControllerName)
+			}
+			if len(gatewayClasses) == 0 {
+				gatewayClasses = nil
+			}
+			if diff := cmp.Diff(tc.expectedGatewayClasses, gatewayClasses); diff != "" {
+				t.Errorf("Unexpected GatewayClasses, diff (-want +got):\n%s", diff)
+			}
+
+			allowedRoutes := map[string]string{}
+			certificateRefs := map[string][]string{}
+			for nn, g := range tc.gatewayResources.Gateways {
+				for _, l := range g.Spec.Listeners {
+					if l.AllowedRoutes != nil && l.AllowedRoutes.Namespaces != nil {
+						allowedRoutes[fmt.Sprintf("%s/%s", nn, l.Name)] = string(ptr.Deref(l.AllowedRoutes.Namespaces.From, ""))
+					}
+					if l.TLS == nil {
+						continue
+					}
+					for _, certificateRef := range l.TLS.CertificateRefs {
+						certificateRefs[nn.String()] = append(certificateRefs[nn.String()], fmt.Sprintf("%s/%s", ptr.Deref(certificateRef.Namespace, ""), certificateRef.Name))
+					}
+				}
+			}
+			if len(allowedRoutes) == 0 {
+				allowedRoutes = nil
+			}
+			if diff := cmp.Diff(tc.expectedAllowedRoutes, allowedRoutes); diff != "" {
+				t.Errorf("Unexpected allowed routes by listener, diff (-want +got):\n%s", diff)
+			}
+			if len(certificateRefs) == 0 {
+				certificateRefs = nil
+			}
+			if diff := cmp.Diff(tc.expectedCertificateRefs, certificateRefs); diff != "" {
+				t.Errorf("Unexpected certificateRefs by Gateway, diff (-want +got):\n%s", diff)
+			}
+
+			referenceGrants := map[string][]string{}
+			for nn, grant := range tc.gatewayResources.ReferenceGrants {
+				grant := grant
+				for _, from := range grant.Spec.From {
+					referenceGrants[nn.String()] = append(referenceGrants[nn.String()], string(from.Namespace))
+				}
+				for _, to := range grant.Spec.To {
+					referenceGrants[nn.String()] = append(referenceGrants[nn.String()], string(ptr.Deref(to.Name, "")))
+				}
+				if len(tc.gatewayResources.Sources[ObjectRefFor(&grant)]) == 0 {
+					t.Errorf("Expected ReferenceGrant %s to have sources", nn)
+				}
+			}
+			if len(referenceGrants) == 0 {
+				referenceGrants = nil
+			}
+			if diff := cmp.Diff(tc.expectedReferenceGrants, referenceGrants); diff != "" {
+				t.Errorf("Unexpected ReferenceGrants, diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// MigrationPlan describes, for a source object such as an Ingress, the Gateway
+// API objects it was converted to, and how to cut over to them.
+type MigrationPlan struct {
+	Source ObjectRef
+
+	// The generated objects, by kind.
+	GatewayClasses  []ObjectRef
+	Gateways        []ObjectRef
+	ReferenceGrants []ObjectRef
+	Routes          []ObjectRef
+	Policies        []ObjectRef
+
+	// Listeners are the names of the listeners the routes attach to, by Gateway.
+	Listeners map[types.NamespacedName][]gatewayv1.SectionName
+
+	// Hostnames are the hostnames served by the routes.
+	Hostnames []string
+
+	// LostFeatures are the warnings and errors notified about the source.
+	LostFeatures []notifications.Notification
+}
+
+// routeInfo holds the route fields needed to find the listeners a route
+// attaches to.
+type routeInfo struct {
+	parentRefs []gatewayv1.ParentReference
+	hostnames  []gatewayv1.Hostname
+}
+
+// BuildMigrationPlans returns a MigrationPlan for every source object the given
+// GatewayResources were converted from, sorted by source. The warnings and
+// errors among the notifications are reported as the features lost by their
+// calling objects.
+func BuildMigrationPlans(gatewayResources []GatewayResources, notificationsByProvider map[string][]notifications.Notification) []MigrationPlan {
+	gateways := map[types.NamespacedName][]gatewayv1.Listener{}
+	routes := map[ObjectRef]routeInfo{}
+	for _, gr := range gatewayResources {
+		for _, gateway := range gr.Gateways {
+			nn := types.NamespacedName{Namespace: gateway.Namespace, Name: gateway.Name}
+			gateways[nn] = append(gateways[nn], gateway.Spec.Listeners...)
+		}
+		for _, route := range gr.HTTPRoutes {
+			route := route
+			routes[ObjectRefFor(&route)] = routeInfo{parentRefs: route.Spec.ParentRefs, hostnames: route.Spec.Hostnames}
+		}
+		for _, route := range gr.TLSRoutes {
+			route := route
+			routes[ObjectRefFor(&route)] = routeInfo{parentRefs: route.Spec.ParentRefs, hostnames: route.Spec.Hostnames}
+		}
+		for _, route := range gr.TCPRoutes {
+			route := route
+			routes[ObjectRefFor(&route)] = routeInfo{parentRefs: route.Spec.ParentRefs}
+		}
+		for _, route := range gr.UDPRoutes {
+			route := route
+			routes[ObjectRefFor(&route)] = routeInfo{parentRefs: route.Spec.ParentRefs}
+		}
+	}
+
+	plans := map[ObjectRef]*MigrationPlan{}
+	for _, gr := range gatewayResources {
+		for obj, sources := range gr.Sources {
+			for _, source := range sources {
+				plan := plans[source]
+				if plan == nil {
+					plan = &MigrationPlan{Source: source, Listeners: map[types.NamespacedName][]gatewayv1.SectionName{}}
+					plans[source] = plan
+				}
+				plan.addObject(obj, routes, gateways)
+			}
+		}
+	}
+
+	// The notifications are about source objects, or about the objects
+	// generated from them, whose plans are found through their sources.
+	plansByRef := map[ObjectRef][]*MigrationPlan{}
+	for source, plan := range plans {
+		plansByRef[kindRef(source)] = append(plansByRef[kindRef(source)], plan)
+	}
+	for _, gr := range gatewayResources {
+		for obj, sources := range gr.Sources {
+			for _, source := range sources {
+				plansByRef[kindRef(obj)] = append(plansByRef[kindRef(obj)], plans[source])
+			}
+		}
+	}
+
+	providers := make([]string, 0, len(notificationsByProvider))
+	for provider := range notificationsByProvider {
+		providers = append(providers, provider)
+	}
+	slices.Sort(providers)
+	for _, provider := range providers {
+		for _, n := range notificationsByProvider[provider] {
+			if n.Type == notifications.InfoNotification {
+				continue
+			}
+			var notified []*MigrationPlan
+			for _, callingObject := range n.CallingObjects {
+				for _, plan := range plansByRef[callingObjectRef(callingObject)] {
+					if !slices.Contains(notified, plan) {
+						plan.LostFeatures = append(plan.LostFeatures, n)
+						notified = append(notified, plan)
+					}
+				}
+			}
+		}
+	}
+
+	result := make([]MigrationPlan, 0, len(plans))
+	for _, plan := range plans {
+		for _, refs := range [][]ObjectRef{plan.GatewayClasses, plan.Gateways, plan.ReferenceGrants, plan.Routes, plan.Policies} {
+			slices.SortFunc(refs, compareObjectRefs)
+		}
+		slices.Sort(plan.Hostnames)
+		for _, listeners := range plan.Listeners {
+			slices.Sort(listeners)
+		}
+		result = append(result, *plan)
+	}
+	slices.SortFunc(result, func(a, b MigrationPlan) int {
+		return compareObjectRefs(a.Source, b.Source)
+	})
+	return result
+}
+
+// kindRef returns the reference to the object of the same kind, namespace and
+// name as ref, regardless of its group and version, which are not always set
+// on the calling objects of the notifications.
+func kindRef(ref ObjectRef) ObjectRef {
+	return ObjectRef{GroupVersionKind: schema.GroupVersionKind{Kind: ref.Kind}, NamespacedName: ref.NamespacedName}
+}
+
+// callingObjectRef returns the kindRef of the calling object of a
+// notification. The kind of typed objects, e.g. the Ingresses read from a
+// cluster, is not always set, in which case it is the name of their type.
+func callingObjectRef(obj client.Object) ObjectRef {
+	ref := kindRef(ObjectRefFor(obj))
+	if ref.Kind == "" {
+		ref.Kind = reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+	}
+	return ref
+}
+
+func (p *MigrationPlan) addObject(obj ObjectRef, routes map[ObjectRef]routeInfo, gateways map[types.NamespacedName][]gatewayv1.Listener) {
+	switch obj.Kind {
+	case "GatewayClass":
+		p.GatewayClasses = append(p.GatewayClasses, obj)
+	case "Gateway":
+		p.Gateways = append(p.Gateways, obj)
+	case "ReferenceGrant":
+		p.ReferenceGrants = append(p.ReferenceGrants, obj)
+	default:
+		// The implementation-specific policies are the only objects
+		// generated out of the Gateway API group.
+		if obj.Group != gatewayv1.GroupName {
+			p.Policies = append(p.Policies, obj)
+			return
+		}
+		p.Routes = append(p.Routes, obj)
+	}
+
+	route, ok := routes[obj]
+	if !ok {
+		return
+	}
+	for _, hostname := range route.hostnames {
+		if !slices.Contains(p.Hostnames, string(hostname)) {
+			p.Hostnames = append(p.Hostnames, string(hostname))
+		}
+	}
+	for _, parentRef := range route.parentRefs {
+		gatewayKey, ok := parentRefGateway(obj.Namespace, parentRef)
+		if !ok {
+			continue
+		}
+		for _, listener := range gateways[gatewayKey] {
+			if !listenerAccepts(listener, parentRef, route.hostnames) || slices.Contains(p.Listeners[gatewayKey], listener.Name) {
+				continue
+			}
+			p.Listeners[gatewayKey] = append(p.Listeners[gatewayKey], listener.Name)
+			if len(route.hostnames) == 0 && listener.Hostname != nil && !slices.Contains(p.Hostnames, string(*listener.Hostname)) {
+				p.Hostnames = append(p.Hostnames, string(*listener.Hostname))
+			}
+		}
+	}
+}
+
+// CutOverSteps returns the steps to cut over from the source object to the
+// Gateway API objects: the Gateways are applied first, then the
+// ReferenceGrants and the routes, before switching the DNS records and
+// deleting the source object.
+func (p MigrationPlan) CutOverSteps() []string {
+	var steps []string
+	for _, gatewayClass := range p.GatewayClasses {
+		steps = append(steps, fmt.Sprintf("Apply GatewayClass %s.", gatewayClass.Name))
+	}
+
+	var gateways []string
+	for _, gateway := range p.Gateways {
+		gateways = append(gateways, gateway.NamespacedName.String())
+	}
+	for gateway := range p.Listeners {
+		if !slices.Contains(gateways, gateway.String()) {
+			gateways = append(gateways, gateway.String())
+		}
+	}
+	slices.Sort(gateways)
+	for _, gateway := range gateways {
+		steps = append(steps, fmt.Sprintf("Apply Gateway %s and wait for it to be programmed.", gateway))
+	}
+
+	for _, referenceGrant := range p.ReferenceGrants {
+		steps = append(steps, fmt.Sprintf("Apply ReferenceGrant %s.", referenceGrant.NamespacedName))
+	}
+	for _, route := range p.Routes {
+		steps = append(steps, fmt.Sprintf("Apply %s %s and wait for it to be accepted.", route.Kind, route.NamespacedName))
+	}
+	for _, policy := range p.Policies {
+		steps = append(steps, fmt.Sprintf("Apply %s %s.", policy.Kind, policy.NamespacedName))
+	}
+
+	if len(gateways) > 0 {
+		target := fmt.Sprintf("the addresses of Gateway %s", strings.Join(gateways, ", "))
+		if len(p.Hostnames) > 0 {
+			steps = append(steps, fmt.Sprintf("Switch the DNS records of %s to %s.", strings.Join(p.Hostnames, ", "), target))
+		} else {
+			steps = append(steps, fmt.Sprintf("Switch the clients of %s %s to %s.", p.Source.Kind, p.Source.NamespacedName, target))
+		}
+	}
+
+	return append(steps, fmt.Sprintf("Delete %s %s.", p.Source.Kind, p.Source.NamespacedName))
+}
+
+// parentRefGateway returns the Gateway referenced by the parentRef of a route
+// in the given namespace, or false if the parent is not a Gateway.
+func parentRefGateway(routeNamespace string, parentRef gatewayv1.ParentReference) (types.NamespacedName, bool) {
+	if (parentRef.Group != nil && *parentRef.Group != gatewayv1.GroupName) || (parentRef.Kind != nil && *parentRef.Kind != "Gateway") {
+		return types.NamespacedName{}, false
+	}
+	gatewayKey := types.NamespacedName{Namespace: routeNamespace, Name: string(parentRef.Name)}
+	if parentRef.Namespace != nil {
+		gatewayKey.Namespace = string(*parentRef.Namespace)
+	}
+	return gatewayKey, true
+}
+
+// listenerAccepts returns whether a route with the given parentRef and
+// hostnames attaches to the listener.
+func listenerAccepts(listener gatewayv1.Listener, parentRef gatewayv1.ParentReference, hostnames []gatewayv1.Hostname) bool {
+	if parentRef.SectionName != nil && *parentRef.SectionName != listener.Name {
+		return false
+	}
+	if parentRef.Port != nil && *parentRef.Port != listener.Port {
+		return false
+	}
+	if listener.Hostname == nil || *listener.Hostname == "" || len(hostnames) == 0 {
+		return true
+	}
+	for _, hostname := range hostnames {
+		if hostnamesMatch(string(*listener.Hostname), string(hostname)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hostnamesMatch returns whether the hostnames, which may be wildcards, have
+// at least one host in common.
+func hostnamesMatch(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if strings.HasPrefix(a, "*.") && strings.HasSuffix(b, a[1:]) {
+		return true
+	}
+	return strings.HasPrefix(b, "*.") && strings.HasSuffix(a, b[1:])
+}
+
+func compareObjectRefs(a, b ObjectRef) int {
+	if a.Kind != b.Kind {
+		return cmp.Compare(a.Kind, b.Kind)
+	}
+	if a.Namespace != b.Namespace {
+		return cmp.Compare(a.Namespace, b.Namespace)
+	}
+	return cmp.Compare(a.Name, b.Name)
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	apiv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func Test_BuildMigrationPlans(t *testing.T) {
+	ingressGVK := schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}
+	gatewayGVK := gatewayv1.SchemeGroupVersion.WithKind("Gateway")
+	httpRouteGVK := gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute")
+
+	webRef := ObjectRef{GroupVersionKind: ingressGVK, NamespacedName: types.NamespacedName{Namespace: "default", Name: "web"}}
+	apiRef := ObjectRef{GroupVersionKind: ingressGVK, NamespacedName: types.NamespacedName{Namespace: "default", Name: "api"}}
+
+	gateway := gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx"},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "nginx",
+			Listeners: []gatewayv1.Listener{
+				{Name: "example-com-http", Hostname: ptr.To(gatewayv1.Hostname("example.com")), Port: 80},
+				{Name: "example-com-https", Hostname: ptr.To(gatewayv1.Hostname("example.com")), Port: 443},
+				{Name: "api-example-com-http", Hostname: ptr.To(gatewayv1.Hostname("api.example.com")), Port: 80},
+			},
+		},
+	}
+	gateway.SetGroupVersionKind(gatewayGVK)
+
+	webRoute := gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-example-com"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "nginx"}}},
+			Hostnames:       []gatewayv1.Hostname{"example.com"},
+		},
+	}
+	webRoute.SetGroupVersionKind(httpRouteGVK)
+
+	apiRoute := gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "api-api-example-com"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "nginx"}}},
+			Hostnames:       []gatewayv1.Hostname{"api.example.com"},
+		},
+	}
+	apiRoute.SetGroupVersionKind(httpRouteGVK)
+
+	webPolicy := unstructured.Unstructured{}
+	webPolicy.SetGroupVersionKind(schema.GroupVersionKind{Group: "gateway.envoyproxy.io", Version: "v1alpha1", Kind: "BackendTrafficPolicy"})
+	webPolicy.SetNamespace("default")
+	webPolicy.SetName("web-example-com")
+
+	gatewayResources := GatewayResources{
+		Gateways: map[types.NamespacedName]gatewayv1.Gateway{{Namespace: "default", Name: "nginx"}: gateway},
+		HTTPRoutes: map[types.NamespacedName]gatewayv1.HTTPRoute{
+			{Namespace: "default", Name: "web-example-com"}:     webRoute,
+			{Namespace: "default", Name: "api-api-example-com"}: apiRoute,
+		},
+		Policies: map[ObjectRef]unstructured.Unstructured{ObjectRefFor(&webPolicy): webPolicy},
+	}
+	gatewayResources.AddSources(&gateway, webRef, apiRef)
+	gatewayResources.AddSources(&webRoute, webRef)
+	gatewayResources.AddSources(&apiRoute, apiRef)
+	gatewayResources.AddSources(&webPolicy, webRef)
+
+	// The kind of Ingresses read from the cluster is not set.
+	webIngress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"}}
+	lostFeature := notifications.NewNotification(notifications.WarningNotification, "unsupported annotation", webIngress)
+	// The notifications about generated objects are reported on their sources,
+	// once.
+	lostRouteFeature := notifications.NewNotification(notifications.WarningNotification, "too many headers", &apiRoute, &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "api"}})
+	notificationsByProvider := map[string][]notifications.Notification{
+		"ingress-nginx": {
+			lostFeature,
+			lostRouteFeature,
+			notifications.NewNotification(notifications.InfoNotification, "converted", webIngress),
+			// A Service of the same name as an Ingress is not the Ingress.
+			notifications.NewNotification(notifications.WarningNotification, "unresolved port", &apiv1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"}}),
+		},
+	}
+
+	plans := BuildMigrationPlans([]GatewayResources{gatewayResources}, notificationsByProvider)
+
+	gatewayRef := ObjectRefFor(&gateway)
+	expectedPlans := []MigrationPlan{
+		{
+			Source:       apiRef,
+			Gateways:     []ObjectRef{gatewayRef},
+			Routes:       []ObjectRef{ObjectRefFor(&apiRoute)},
+			Listeners:    map[types.NamespacedName][]gatewayv1.SectionName{{Namespace: "default", Name: "nginx"}: {"api-example-com-http"}},
+			Hostnames:    []string{"api.example.com"},
+			LostFeatures: []notifications.Notification{lostRouteFeature},
+		},
+		{
+			Source:       webRef,
+			Gateways:     []ObjectRef{gatewayRef},
+			Routes:       []ObjectRef{ObjectRefFor(&webRoute)},
+			Policies:     []ObjectRef{ObjectRefFor(&webPolicy)},
+			Listeners:    map[types.NamespacedName][]gatewayv1.SectionName{{Namespace: "default", Name: "nginx"}: {"example-com-http", "example-com-https"}},
+			Hostnames:    []string{"example.com"},
+			LostFeatures: []notifications.Notification{lostFeature},
+		},
+	}
+	if diff := cmp.Diff(expectedPlans, plans); diff != "" {
+		t.Errorf("Unexpected migration plans (-want +got):\n%s", diff)
+	}
+
+	expectedSteps := []string{
+		"Apply Gateway default/nginx and wait for it to be programmed.",
+		"Apply HTTPRoute default/web-example-com and wait for it to be accepted.",
+		"Apply BackendTrafficPolicy default/web-example-com.",
+		"Switch the DNS records of example.com to the addresses of Gateway default/nginx.",
+		"Delete Ingress default/web.",
+	}
+	if diff := cmp.Diff(expectedSteps, plans[1].CutOverSteps()); diff != "" {
+		t.Errorf("Unexpected cut-over steps (-want +got):\n%s", diff)
+	}
+}
+
+func Test_hostnamesMatch(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected bool
+	}{
+		{a: "example.com", b: "example.com", expected: true},
+		{a: "*.example.com", b: "foo.example.com", expected: true},
+		{a: "foo.example.com", b: "*.example.com", expected: true},
+		{a: "*.example.com", b: "example.com", expected: false},
+		{a: "foo.example.com", b: "bar.example.com", expected: false},
+	}
+	for _, tc := range testCases {
+		if got := hostnamesMatch(tc.a, tc.b); got != tc.expected {
+			t.Errorf("hostnamesMatch(%q, %q) = %t, expected %t", tc.a, tc.b, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"maps"
+	"runtime/debug"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SourceAnnotation lists the objects a generated object was converted
+	// from, as comma-separated <group>/<version>/<kind>/<namespace>/<name>.
+	SourceAnnotation = "ingress2gateway.io/source"
+
+	// VersionAnnotation is the version of ingress2gateway that generated the
+	// object.
+	VersionAnnotation = "ingress2gateway.io/version"
+)
+
+// Version is the version of ingress2gateway. It is set at build time with
+// -ldflags "-X github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw.Version=<version>".
+var Version string
+
+// GetVersion returns Version if set, or else the version of the main module,
+// which is set when installed with go install.
+func GetVersion() string {
+	if Version != "" {
+		return Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
+
+// AddProvenanceAnnotations annotates every object of the GatewayResources with
+// the version of ingress2gateway and, when known, the objects it was
+// converted from.
+func AddProvenanceAnnotations(gatewayResources *GatewayResources) {
+	annotate := func(obj client.Object) {
+		annotations := maps.Clone(obj.GetAnnotations())
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[VersionAnnotation] = GetVersion()
+
+		if sources := gatewayResources.Sources[ObjectRefFor(obj)]; len(sources) > 0 {
+			refs := make([]string, 0, len(sources))
+			for _, source := range sources {
+				refs = append(refs, source.String())
+			}
+			slices.Sort(refs)
+			annotations[SourceAnnotation] = strings.Join(refs, ",")
+		}
+		obj.SetAnnotations(annotations)
+	}
+
+	annotateObjects(gatewayResources.GatewayClasses, annotate)
+	annotateObjects(gatewayResources.Gateways, annotate)
+	annotateObjects(gatewayResources.HTTPRoutes, annotate)
+	annotateObjects(gatewayResources.TLSRoutes, annotate)
+	annotateObjects(gatewayResources.TCPRoutes, annotate)
+	annotateObjects(gatewayResources.UDPRoutes, annotate)
+	annotateObjects(gatewayResources.ReferenceGrants, annotate)
+	for ref, policy := range gatewayResources.Policies {
+		policy := policy
+		annotate(&policy)
+		gatewayResources.Policies[ref] = policy
+	}
+}
+
+// annotateObjects calls annotate on every object of the map, and stores the
+// annotated objects back in the map.
+func annotateObjects[T any, PT interface {
+	*T
+	client.Object
+}](objects map[types.NamespacedName]T, annotate func(client.Object)) {
+	for key, obj := range objects {
+		obj := obj
+		annotate(PT(&obj))
+		objects[key] = obj
+	}
+}
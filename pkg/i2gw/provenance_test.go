@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func Test_AddProvenanceAnnotations(t *testing.T) {
+	Version = "v1.2.3"
+	defer func() { Version = "" }()
+
+	ingressGVK := schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}
+	webRef := ObjectRef{GroupVersionKind: ingressGVK, NamespacedName: types.NamespacedName{Namespace: "default", Name: "web"}}
+	apiRef := ObjectRef{GroupVersionKind: ingressGVK, NamespacedName: types.NamespacedName{Namespace: "default", Name: "api"}}
+
+	sourceAnnotations := map[string]string{"owner": "team-a"}
+	gateway := gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx", Annotations: sourceAnnotations}}
+	gateway.SetGroupVersionKind(gatewayv1.SchemeGroupVersion.WithKind("Gateway"))
+	gatewayClass := gatewayv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "nginx"}}
+	gatewayClass.SetGroupVersionKind(gatewayv1.SchemeGroupVersion.WithKind("GatewayClass"))
+
+	gatewayResources := GatewayResources{
+		GatewayClasses: map[types.NamespacedName]gatewayv1.GatewayClass{{Name: "nginx"}: gatewayClass},
+		Gateways:       map[types.NamespacedName]gatewayv1.Gateway{{Namespace: "default", Name: "nginx"}: gateway},
+	}
+	gatewayResources.AddSources(&gateway, webRef, apiRef)
+
+	AddProvenanceAnnotations(&gatewayResources)
+
+	expectedGatewayAnnotations := map[string]string{
+		"owner":           "team-a",
+		SourceAnnotation:  "networking.k8s.io/v1/Ingress/default/api,networking.k8s.io/v1/Ingress/default/web",
+		VersionAnnotation: "v1.2.3",
+	}
+	if diff := cmp.Diff(expectedGatewayAnnotations, gatewayResources.Gateways[types.NamespacedName{Namespace: "default", Name: "nginx"}].Annotations); diff != "" {
+		t.Errorf("Unexpected Gateway annotations (-want +got):\n%s", diff)
+	}
+
+	// Objects without known sources only get the version.
+	expectedGatewayClassAnnotations := map[string]string{VersionAnnotation: "v1.2.3"}
+	if diff := cmp.Diff(expectedGatewayClassAnnotations, gatewayResources.GatewayClasses[types.NamespacedName{Name: "nginx"}].Annotations); diff != "" {
+		t.Errorf("Unexpected GatewayClass annotations (-want +got):\n%s", diff)
+	}
+
+	if len(sourceAnnotations) != 1 {
+		t.Errorf("Expected the annotations shared with the source to be left unchanged, got %v", sourceAnnotations)
+	}
+}
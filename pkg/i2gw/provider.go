@@ -18,9 +18,14 @@ package i2gw
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"sync"
 
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -47,6 +52,52 @@ type ProviderConf struct {
 	Client                client.Client
 	Namespace             string
 	ProviderSpecificFlags map[string]map[string]string
+
+	// Notifications collects the notifications of the conversion run. It
+	// must be set.
+	Notifications *notifications.NotificationAggregator
+
+	// ListenerConsolidation is how the listeners of the Gateways generated
+	// from Ingresses are consolidated.
+	ListenerConsolidation ListenerConsolidation
+
+	// GatewayNamespace, when set, is the namespace the Gateways generated
+	// from Ingresses are moved to once converted, see GatewayPlacement.
+	GatewayNamespace string
+}
+
+// ListenerConsolidation is a strategy to consolidate the per-host listeners of
+// the Gateways generated from Ingresses.
+type ListenerConsolidation string
+
+const (
+	// NoListenerConsolidation generates an HTTP listener per host, and an
+	// HTTPS listener per TLS host.
+	NoListenerConsolidation ListenerConsolidation = ""
+
+	// WildcardListenerConsolidation generates a single HTTP listener without
+	// hostname, and an HTTPS listener per TLS certificate whose hosts share a
+	// parent domain, with the wildcard hostname of the domain. The TLS hosts of
+	// the other certificates are served by a single HTTPS listener without
+	// hostname, with all their certificates.
+	WildcardListenerConsolidation ListenerConsolidation = "wildcard"
+
+	// HostnamelessListenerConsolidation generates a single HTTP listener and a
+	// single HTTPS listener, with all the certificates, without hostname.
+	HostnamelessListenerConsolidation ListenerConsolidation = "hostnameless"
+)
+
+// ListenerConsolidations are the supported ListenerConsolidation strategies.
+var ListenerConsolidations = []ListenerConsolidation{NoListenerConsolidation, WildcardListenerConsolidation, HostnamelessListenerConsolidation}
+
+// NotificationAggregator returns the aggregator the provider dispatches its
+// notifications to. It panics if Notifications is not set, as the
+// notifications would be lost.
+func (c *ProviderConf) NotificationAggregator() *notifications.NotificationAggregator {
+	if c.Notifications == nil {
+		panic("i2gw: ProviderConf.Notifications is not set")
+	}
+	return c.Notifications
 }
 
 // The Provider interface specifies the required functionality which needs to be
@@ -63,9 +114,9 @@ type CustomResourceReader interface {
 	// the underlying Provider implementation from the kubernetes cluster.
 	ReadResourcesFromCluster(ctx context.Context) error
 
-	// ReadResourcesFromFile reads custom resources associated with
-	// the underlying Provider implementation from the file.
-	ReadResourcesFromFile(ctx context.Context, filename string) error
+	// ReadResourcesFromInputs reads custom resources associated with
+	// the underlying Provider implementation from the inputs.
+	ReadResourcesFromInputs(ctx context.Context, inputs []Input) error
 }
 
 // The ResourceConverter interface specifies all the implemented Gateway API resource
@@ -86,6 +137,17 @@ type ImplementationSpecificHTTPPathTypeMatchConverter func(*gatewayv1.HTTPPathMa
 // implementation-specific fields of the ingress API.
 type ProviderImplementationSpecificOptions struct {
 	ToImplementationSpecificHTTPPathTypeMatch ImplementationSpecificHTTPPathTypeMatchConverter
+
+	// ListenerConsolidation is how the listeners are consolidated, usually
+	// ProviderConf.ListenerConsolidation. The routes attach to the
+	// consolidated listeners by sectionName.
+	ListenerConsolidation ListenerConsolidation
+
+	// GatewayNamespace, when set, is the namespace the Gateways are moved to,
+	// usually ProviderConf.GatewayNamespace. Their certificateRefs then carry
+	// the namespace of the Ingress TLS Secrets, which grant the Gateways of
+	// GatewayNamespace access with a ReferenceGrant.
+	GatewayNamespace string
 }
 
 // GatewayResources contains all Gateway-API objects.
@@ -99,6 +161,86 @@ type GatewayResources struct {
 	UDPRoutes  map[types.NamespacedName]gatewayv1alpha2.UDPRoute
 
 	ReferenceGrants map[types.NamespacedName]gatewayv1beta1.ReferenceGrant
+
+	// Policies are the implementation-specific policies, e.g. Envoy Gateway
+	// BackendTrafficPolicies, carrying the features of the converted
+	// resources that core Gateway API cannot express. They usually target
+	// the generated routes.
+	Policies map[ObjectRef]unstructured.Unstructured
+
+	// Sources maps every generated object to the objects, e.g. Ingresses, it
+	// was converted from. It is used to report on the conversion, and is not
+	// part of the output.
+	Sources Sources
+
+	// SourceClasses maps the Gateways generated from classed resources, e.g.
+	// Ingresses, to the class of these resources, e.g. their ingress class.
+	// It is used to apply the ClassMapping, and is not part of the output.
+	SourceClasses map[types.NamespacedName]string
+}
+
+// Sources maps generated objects to the objects they were converted from.
+type Sources map[ObjectRef][]ObjectRef
+
+// Add records that the generated object was converted from the given sources.
+func (s Sources) Add(obj ObjectRef, sources ...ObjectRef) {
+	for _, source := range sources {
+		if !slices.Contains(s[obj], source) {
+			s[obj] = append(s[obj], source)
+		}
+	}
+}
+
+// ObjectRef identifies an object by its GroupVersionKind, namespace and name.
+type ObjectRef struct {
+	schema.GroupVersionKind
+	types.NamespacedName
+}
+
+// ObjectRefFor returns the ObjectRef of the given object, which must have its
+// GroupVersionKind set.
+func ObjectRefFor(obj client.Object) ObjectRef {
+	return ObjectRef{
+		GroupVersionKind: obj.GetObjectKind().GroupVersionKind(),
+		NamespacedName:   client.ObjectKeyFromObject(obj),
+	}
+}
+
+// String returns the reference as <group>/<version>/<kind>/<namespace>/<name>,
+// e.g. networking.k8s.io/v1/Ingress/default/example. The group is omitted for
+// the core group, and the namespace for cluster-scoped objects.
+func (r ObjectRef) String() string {
+	parts := []string{r.GroupVersion().String(), r.Kind}
+	if r.Namespace != "" {
+		parts = append(parts, r.Namespace)
+	}
+	return strings.Join(append(parts, r.Name), "/")
+}
+
+// AddSources records that the generated object was converted from the given
+// sources.
+func (gr *GatewayResources) AddSources(obj client.Object, sources ...ObjectRef) {
+	if gr.Sources == nil {
+		gr.Sources = Sources{}
+	}
+	gr.Sources.Add(ObjectRefFor(obj), sources...)
+}
+
+// AttachedListeners returns the listeners a route in routeNamespace, with the
+// given parentRef and hostnames, attaches to. It returns nil when the
+// parentRef does not reference one of the Gateways.
+func (gr *GatewayResources) AttachedListeners(routeNamespace string, parentRef gatewayv1.ParentReference, hostnames []gatewayv1.Hostname) []gatewayv1.Listener {
+	gatewayKey, ok := parentRefGateway(routeNamespace, parentRef)
+	if !ok {
+		return nil
+	}
+	var listeners []gatewayv1.Listener
+	for _, listener := range gr.Gateways[gatewayKey].Spec.Listeners {
+		if listenerAccepts(listener, parentRef, hostnames) {
+			listeners = append(listeners, listener)
+		}
+	}
+	return listeners
 }
 
 // FeatureParser is a function that reads the Ingresses, and applies
@@ -106,7 +248,8 @@ type GatewayResources struct {
 //
 // Different FeatureParsers will run in undetermined order. The function must
 // modify / create only the required fields of the gateway resources and nothing else.
-type FeatureParser func([]networkingv1.Ingress, *GatewayResources) field.ErrorList
+// Notifications are dispatched to the given aggregator of the conversion run.
+type FeatureParser func([]networkingv1.Ingress, *GatewayResources, *notifications.NotificationAggregator) field.ErrorList
 
 var providerSpecificFlagDefinitions = providerSpecificFlags{
 	flags: make(map[ProviderName]map[string]ProviderSpecificFlag),
@@ -44,7 +44,7 @@ func NewProvider(conf *i2gw.ProviderConf) i2gw.Provider {
 	return &Provider{
 		storage:        newResourcesStorage(),
 		resourceReader: newResourceReader(conf),
-		converter:      newConverter(),
+		converter:      newConverter(conf),
 	}
 }
 
@@ -64,10 +64,10 @@ func (p *Provider) ReadResourcesFromCluster(ctx context.Context) error {
 	return nil
 }
 
-func (p *Provider) ReadResourcesFromFile(_ context.Context, filename string) error {
-	storage, err := p.resourceReader.readResourcesFromFile(filename)
+func (p *Provider) ReadResourcesFromInputs(_ context.Context, inputs []i2gw.Input) error {
+	storage, err := p.resourceReader.readResourcesFromInputs(inputs)
 	if err != nil {
-		return fmt.Errorf("failed to read resources from file: %w", err)
+		return fmt.Errorf("failed to read resources from the inputs: %w", err)
 	}
 
 	p.storage = storage
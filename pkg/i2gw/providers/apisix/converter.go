@@ -25,18 +25,23 @@ import (
 
 // converter implements the ToGatewayAPI function of i2gw.ResourceConverter interface.
 type converter struct {
+	conf *i2gw.ProviderConf
+
 	featureParsers                []i2gw.FeatureParser
 	implementationSpecificOptions i2gw.ProviderImplementationSpecificOptions
 }
 
 // newConverter returns an apisix converter instance.
-func newConverter() *converter {
+func newConverter(conf *i2gw.ProviderConf) *converter {
 	return &converter{
+		conf: conf,
 		featureParsers: []i2gw.FeatureParser{
 			httpToHTTPSFeature,
 		},
 		implementationSpecificOptions: i2gw.ProviderImplementationSpecificOptions{
 			// The list of the implementationSpecific ingress fields options comes here.
+			ListenerConsolidation: conf.ListenerConsolidation,
+			GatewayNamespace:      conf.GatewayNamespace,
 		},
 	}
 }
@@ -46,6 +51,10 @@ func (c *converter) convert(storage *storage) (i2gw.GatewayResources, field.Erro
 	for _, ing := range storage.Ingresses {
 		ingressList = append(ingressList, *ing)
 	}
+
+	ingressList, notificationsAggregator := common.ResolveNamedServicePorts(ingressList, storage.ServicePorts)
+	dispatchNotification(c.conf.NotificationAggregator(), notificationsAggregator)
+
 	// Convert plain ingress resources to gateway resources, ignoring all
 	// provider-specific features.
 	gatewayResources, errs := common.ToGateway(ingressList, c.implementationSpecificOptions)
@@ -55,7 +64,7 @@ func (c *converter) convert(storage *storage) (i2gw.GatewayResources, field.Erro
 
 	for _, parseFeatureFunc := range c.featureParsers {
 		// Apply the feature parsing function to the gateway resources, one by one.
-		parseErrs := parseFeatureFunc(ingressList, &gatewayResources)
+		parseErrs := parseFeatureFunc(ingressList, &gatewayResources, c.conf.NotificationAggregator())
 		// Append the parsing errors to the error list.
 		errs = append(errs, parseErrs...)
 	}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -264,7 +265,7 @@ func Test_httpToHttpsFeature(t *testing.T) {
 				},
 			}
 
-			errs := httpToHTTPSFeature(ingresses, gatewayResources)
+			errs := httpToHTTPSFeature(ingresses, gatewayResources, notifications.NewNotificationAggregator())
 
 			if len(errs) != len(tc.expectedError) {
 				t.Errorf("expected %d errors, got %d", len(tc.expectedError), len(errs))
@@ -45,17 +45,30 @@ func (r *resourceReader) readResourcesFromCluster(ctx context.Context) (*storage
 		return nil, err
 	}
 	storage.Ingresses = ingresses
+
+	services, serviceNotifications, err := common.ReadServicesFromCluster(ctx, r.conf.Client)
+	if err != nil {
+		return nil, err
+	}
+	dispatchNotification(r.conf.NotificationAggregator(), serviceNotifications)
+	storage.ServicePorts = common.GroupServicePortsByPortName(services)
 	return storage, nil
 }
 
-func (r *resourceReader) readResourcesFromFile(filename string) (*storage, error) {
+func (r *resourceReader) readResourcesFromInputs(inputs []i2gw.Input) (*storage, error) {
 	// read apisix related resources from file.
 	storage := newResourcesStorage()
 
-	ingresses, err := common.ReadIngressesFromFile(filename, r.conf.Namespace, sets.New[string](ApisixIngressClass))
+	ingresses, err := common.ReadIngressesFromInputs(inputs, r.conf.Namespace, sets.New[string](ApisixIngressClass))
 	if err != nil {
 		return nil, err
 	}
 	storage.Ingresses = ingresses
+
+	services, err := common.ReadServicesFromInputs(inputs, r.conf.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	storage.ServicePorts = common.GroupServicePortsByPortName(services)
 	return storage, nil
 }
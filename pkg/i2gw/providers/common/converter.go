@@ -29,12 +29,16 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 // ToGateway converts the received ingresses to i2gw.GatewayResources,
 // without taking into consideration any provider specific logic.
 func ToGateway(ingresses []networkingv1.Ingress, options i2gw.ProviderImplementationSpecificOptions) (i2gw.GatewayResources, field.ErrorList) {
-	aggregator := ingressAggregator{ruleGroups: map[ruleGroupKey]*ingressRuleGroup{}}
+	aggregator := ingressAggregator{
+		ruleGroups: map[ruleGroupKey]*ingressRuleGroup{},
+		sources:    i2gw.Sources{},
+	}
 
 	var errs field.ErrorList
 	for _, ingress := range ingresses {
@@ -56,18 +60,58 @@ func ToGateway(ingresses []networkingv1.Ingress, options i2gw.ProviderImplementa
 	}
 
 	gatewayByKey := make(map[types.NamespacedName]gatewayv1.Gateway)
+	// The Gateways are named after the ingress class of their Ingresses.
+	sourceClasses := make(map[types.NamespacedName]string)
 	for _, gateway := range gateways {
 		key := types.NamespacedName{Namespace: gateway.Namespace, Name: gateway.Name}
 		gatewayByKey[key] = gateway
+		sourceClasses[key] = gateway.Name
 	}
 
 	return i2gw.GatewayResources{
-		Gateways:   gatewayByKey,
-		HTTPRoutes: routeByKey,
+		Gateways:        gatewayByKey,
+		HTTPRoutes:      routeByKey,
+		ReferenceGrants: aggregator.tlsSecretReferenceGrants(gateways, options.GatewayNamespace),
+		Sources:         aggregator.sources,
+		SourceClasses:   sourceClasses,
 	}, nil
 }
 
+// tlsSecretReferenceGrants returns the ReferenceGrants allowing the Gateways
+// of gatewayNamespace to reference the TLS Secrets of the other namespaces,
+// one per Secret namespace, see i2gw.GrantTLSSecretReference.
+func (a *ingressAggregator) tlsSecretReferenceGrants(gateways []gatewayv1.Gateway, gatewayNamespace string) map[types.NamespacedName]gatewayv1beta1.ReferenceGrant {
+	if gatewayNamespace == "" {
+		return nil
+	}
+	grants := make(map[types.NamespacedName]gatewayv1beta1.ReferenceGrant)
+	for _, gateway := range gateways {
+		gateway := gateway
+		for _, listener := range gateway.Spec.Listeners {
+			if listener.TLS == nil {
+				continue
+			}
+			for _, certificateRef := range listener.TLS.CertificateRefs {
+				if certificateRef.Namespace == nil || string(*certificateRef.Namespace) == gatewayNamespace {
+					continue
+				}
+				secret := types.NamespacedName{Namespace: string(*certificateRef.Namespace), Name: string(certificateRef.Name)}
+				grantKey := i2gw.GrantTLSSecretReference(grants, gatewayNamespace, secret)
+				grant := grants[grantKey]
+				a.sources.Add(i2gw.ObjectRefFor(&grant), a.sources[i2gw.ObjectRefFor(&gateway)]...)
+			}
+		}
+	}
+	return grants
+}
+
 var (
+	IngressGVK = schema.GroupVersionKind{
+		Group:   "networking.k8s.io",
+		Version: "v1",
+		Kind:    "Ingress",
+	}
+
 	GatewayGVK = schema.GroupVersionKind{
 		Group:   "gateway.networking.k8s.io",
 		Version: "v1",
@@ -104,6 +148,9 @@ type ruleGroupKey string
 type ingressAggregator struct {
 	ruleGroups      map[ruleGroupKey]*ingressRuleGroup
 	defaultBackends []ingressDefaultBackend
+
+	// sources maps the generated objects to the Ingresses they come from.
+	sources i2gw.Sources
 }
 
 type pathMatchKey string
@@ -115,6 +162,7 @@ type ingressRuleGroup struct {
 	host         string
 	tls          []networkingv1.IngressTLS
 	rules        []ingressRule
+	sources      []i2gw.ObjectRef
 }
 
 type ingressRule struct {
@@ -126,6 +174,7 @@ type ingressDefaultBackend struct {
 	namespace    string
 	ingressClass string
 	backend      networkingv1.IngressBackend
+	source       i2gw.ObjectRef
 }
 
 type ingressPath struct {
@@ -146,6 +195,7 @@ func (a *ingressAggregator) addIngress(ingress networkingv1.Ingress) {
 			namespace:    ingress.Namespace,
 			ingressClass: ingressClass,
 			backend:      *ingress.Spec.DefaultBackend,
+			source:       ingressRef(ingress.Namespace, ingress.Name),
 		})
 	}
 }
@@ -166,12 +216,28 @@ func (a *ingressAggregator) addIngressRule(namespace, name, ingressClass string,
 		rg.tls = append(rg.tls, iSpec.TLS...)
 	}
 	rg.rules = append(rg.rules, ingressRule{rule: rule})
+	if source := ingressRef(namespace, name); !slices.Contains(rg.sources, source) {
+		rg.sources = append(rg.sources, source)
+	}
+}
+
+// ingressRef returns the ObjectRef of an Ingress, whose GroupVersionKind is not
+// set when read from the cluster.
+func ingressRef(namespace, name string) i2gw.ObjectRef {
+	return i2gw.ObjectRef{
+		GroupVersionKind: IngressGVK,
+		NamespacedName:   types.NamespacedName{Namespace: namespace, Name: name},
+	}
 }
 
 func (a *ingressAggregator) toHTTPRoutesAndGateways(options i2gw.ProviderImplementationSpecificOptions) ([]gatewayv1.HTTPRoute, []gatewayv1.Gateway, field.ErrorList) {
 	var httpRoutes []gatewayv1.HTTPRoute
 	var errors field.ErrorList
 	listenersByNamespacedGateway := map[string][]gatewayv1.Listener{}
+	sourcesByNamespacedGateway := map[string][]i2gw.ObjectRef{}
+	// routeIndexesByNamespacedGateway are the indexes in httpRoutes of the
+	// routes of every listener of listenersByNamespacedGateway.
+	routeIndexesByNamespacedGateway := map[string][]int{}
 
 	// Sort the rulegroups to iterate the map in a sorted order.
 	ruleGroupsKeys := make([]ruleGroupKey, 0, len(a.ruleGroups))
@@ -195,13 +261,21 @@ func (a *ingressAggregator) toHTTPRoutesAndGateways(options i2gw.ProviderImpleme
 			listener.TLS = &gatewayv1.GatewayTLSConfig{}
 		}
 		for _, tls := range rg.tls {
-			listener.TLS.CertificateRefs = append(listener.TLS.CertificateRefs,
-				gatewayv1.SecretObjectReference{Name: gatewayv1.ObjectName(tls.SecretName)})
+			certificateRef := gatewayv1.SecretObjectReference{Name: gatewayv1.ObjectName(tls.SecretName)}
+			// Ingress TLS Secrets are always in the namespace of the Ingress,
+			// which is made explicit for the Gateway to be moved.
+			if options.GatewayNamespace != "" {
+				certificateRef.Namespace = PtrTo(gatewayv1.Namespace(rg.namespace))
+			}
+			listener.TLS.CertificateRefs = append(listener.TLS.CertificateRefs, certificateRef)
 		}
 		gwKey := fmt.Sprintf("%s/%s", rg.namespace, rg.ingressClass)
 		listenersByNamespacedGateway[gwKey] = append(listenersByNamespacedGateway[gwKey], listener)
+		sourcesByNamespacedGateway[gwKey] = append(sourcesByNamespacedGateway[gwKey], rg.sources...)
+		routeIndexesByNamespacedGateway[gwKey] = append(routeIndexesByNamespacedGateway[gwKey], len(httpRoutes))
 		httpRoute, errs := rg.toHTTPRoute(options)
 		httpRoutes = append(httpRoutes, httpRoute)
+		a.sources.Add(i2gw.ObjectRefFor(&httpRoute), rg.sources...)
 		errors = append(errors, errs...)
 	}
 
@@ -226,16 +300,17 @@ func (a *ingressAggregator) toHTTPRoutesAndGateways(options i2gw.ProviderImpleme
 		}
 		httpRoute.SetGroupVersionKind(HTTPRouteGVK)
 
-		backendRef, err := toBackendRef(db.backend, field.NewPath(db.name, "paths", "backends").Index(i))
+		backendRef, err := ToBackendRef(db.backend, field.NewPath(db.name, "paths", "backends").Index(i))
 		if err != nil {
 			errors = append(errors, err)
-		} else {
+		} else if backendRef != nil {
 			httpRoute.Spec.Rules = append(httpRoute.Spec.Rules, gatewayv1.HTTPRouteRule{
 				BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: *backendRef}},
 			})
 		}
 
 		httpRoutes = append(httpRoutes, httpRoute)
+		a.sources.Add(i2gw.ObjectRefFor(&httpRoute), db.source)
 	}
 
 	gatewaysByKey := map[string]*gatewayv1.Gateway{}
@@ -259,6 +334,28 @@ func (a *ingressAggregator) toHTTPRoutesAndGateways(options i2gw.ProviderImpleme
 			gateway.SetGroupVersionKind(GatewayGVK)
 			gatewaysByKey[gwKey] = gateway
 		}
+		a.sources.Add(i2gw.ObjectRefFor(gateway), sourcesByNamespacedGateway[gwKey]...)
+
+		if options.ListenerConsolidation != i2gw.NoListenerConsolidation {
+			consolidatedListeners, sectionNames := consolidateListeners(listeners, options.ListenerConsolidation)
+			gateway.Spec.Listeners = append(gateway.Spec.Listeners, consolidatedListeners...)
+			// The routes attach to the listeners serving their host.
+			for i, routeIdx := range routeIndexesByNamespacedGateway[gwKey] {
+				if len(httpRoutes[routeIdx].Spec.ParentRefs) == 0 {
+					continue
+				}
+				var parentRefs []gatewayv1.ParentReference
+				for _, sectionName := range sectionNames[i] {
+					parentRefs = append(parentRefs, gatewayv1.ParentReference{
+						Name:        gatewayv1.ObjectName(parts[1]),
+						SectionName: PtrTo(sectionName),
+					})
+				}
+				httpRoutes[routeIdx].Spec.ParentRefs = parentRefs
+			}
+			continue
+		}
+
 		for _, listener := range listeners {
 			var listenerNamePrefix string
 			if listener.Hostname != nil && *listener.Hostname != "" {
@@ -343,11 +440,14 @@ func (rg *ingressRuleGroup) configureBackendRef(paths []ingressPath) ([]gatewayv
 	var backendRefs []gatewayv1.HTTPBackendRef
 
 	for i, path := range paths {
-		backendRef, err := toBackendRef(path.path.Backend, field.NewPath("paths", "backends").Index(i))
+		backendRef, err := ToBackendRef(path.path.Backend, field.NewPath("paths", "backends").Index(i))
 		if err != nil {
 			errors = append(errors, err)
 			continue
 		}
+		if backendRef == nil {
+			continue
+		}
 		backendRefs = append(backendRefs, gatewayv1.HTTPBackendRef{BackendRef: *backendRef})
 	}
 
@@ -396,24 +496,129 @@ func toHTTPRouteMatch(routePath networkingv1.HTTPIngressPath, path *field.Path,
 	return match, nil
 }
 
-func toBackendRef(ib networkingv1.IngressBackend, path *field.Path) (*gatewayv1.BackendRef, *field.Error) {
-	if ib.Service != nil {
-		if ib.Service.Port.Name != "" {
-			fieldPath := path.Child("service", "port")
-			return nil, field.Invalid(fieldPath, "name", fmt.Sprintf("named ports not supported: %s", ib.Service.Port.Name))
+// consolidateListeners returns the consolidated listeners serving the hosts
+// of the per-host listeners, and the names of the listeners serving every
+// host. Every host is served by a single HTTP listener without hostname, and
+// the TLS hosts by HTTPS listeners depending on the consolidation:
+//   - with WildcardListenerConsolidation, the hosts of a TLS certificate
+//     sharing a parent domain are served by a listener with the wildcard
+//     hostname of the domain, provided it matches no host of another
+//     certificate;
+//   - the other TLS hosts are served by a single listener without hostname,
+//     with the certificates of all of them.
+func consolidateListeners(hostListeners []gatewayv1.Listener, consolidation i2gw.ListenerConsolidation) ([]gatewayv1.Listener, [][]gatewayv1.SectionName) {
+	listeners := []gatewayv1.Listener{{
+		Name:     "http",
+		Port:     80,
+		Protocol: gatewayv1.HTTPProtocolType,
+	}}
+	sectionNames := make([][]gatewayv1.SectionName, len(hostListeners))
+	for i := range hostListeners {
+		sectionNames[i] = []gatewayv1.SectionName{"http"}
+	}
+
+	// The TLS hosts are grouped by certificate.
+	type certificateGroup struct {
+		certificateRefs []gatewayv1.SecretObjectReference
+		hosts           []string
+		indexes         []int
+	}
+	var certificateKeys []string
+	groupByCertificate := map[string]*certificateGroup{}
+	for i, listener := range hostListeners {
+		if listener.TLS == nil {
+			continue
+		}
+		var secretNames []string
+		for _, certificateRef := range listener.TLS.CertificateRefs {
+			secretNames = append(secretNames, string(certificateRef.Name))
+		}
+		slices.Sort(secretNames)
+		key := strings.Join(slices.Compact(secretNames), ",")
+
+		group, ok := groupByCertificate[key]
+		if !ok {
+			group = &certificateGroup{certificateRefs: listener.TLS.CertificateRefs}
+			groupByCertificate[key] = group
+			certificateKeys = append(certificateKeys, key)
+		}
+		host := ""
+		if listener.Hostname != nil {
+			host = string(*listener.Hostname)
+		}
+		group.hosts = append(group.hosts, host)
+		group.indexes = append(group.indexes, i)
+	}
+	slices.Sort(certificateKeys)
+
+	var hostnameless *gatewayv1.Listener
+	for _, key := range certificateKeys {
+		group := groupByCertificate[key]
+
+		var otherHosts []string
+		for _, otherKey := range certificateKeys {
+			if otherKey != key {
+				otherHosts = append(otherHosts, groupByCertificate[otherKey].hosts...)
+			}
+		}
+
+		var sectionName gatewayv1.SectionName
+		if wildcard, ok := wildcardHostname(group.hosts, otherHosts); ok && consolidation == i2gw.WildcardListenerConsolidation {
+			sectionName = gatewayv1.SectionName(fmt.Sprintf("wildcard-%s-https", NameFromHost(wildcard)))
+			listeners = append(listeners, gatewayv1.Listener{
+				Name:     sectionName,
+				Hostname: PtrTo(gatewayv1.Hostname(wildcard)),
+				Port:     443,
+				Protocol: gatewayv1.HTTPSProtocolType,
+				TLS:      &gatewayv1.GatewayTLSConfig{CertificateRefs: group.certificateRefs},
+			})
+		} else {
+			if hostnameless == nil {
+				hostnameless = &gatewayv1.Listener{
+					Name:     "https",
+					Port:     443,
+					Protocol: gatewayv1.HTTPSProtocolType,
+					TLS:      &gatewayv1.GatewayTLSConfig{},
+				}
+			}
+			for _, certificateRef := range group.certificateRefs {
+				if !slices.Contains(hostnameless.TLS.CertificateRefs, certificateRef) {
+					hostnameless.TLS.CertificateRefs = append(hostnameless.TLS.CertificateRefs, certificateRef)
+				}
+			}
+			sectionName = hostnameless.Name
+		}
+		for _, i := range group.indexes {
+			sectionNames[i] = append(sectionNames[i], sectionName)
+		}
+	}
+	if hostnameless != nil {
+		listeners = append(listeners, *hostnameless)
+	}
+
+	return listeners, sectionNames
+}
+
+// wildcardHostname returns the wildcard hostname of the parent domain shared by
+// the hosts, e.g. *.example.com for foo.example.com and bar.example.com, if it
+// matches none of the other hosts, which are served with other certificates.
+func wildcardHostname(hosts, otherHosts []string) (string, bool) {
+	var parent string
+	for _, host := range hosts {
+		i := strings.Index(host, ".")
+		// The parent domain must not be a top-level domain.
+		if i < 0 || !strings.Contains(host[i+1:], ".") {
+			return "", false
 		}
-		return &gatewayv1.BackendRef{
-			BackendObjectReference: gatewayv1.BackendObjectReference{
-				Name: gatewayv1.ObjectName(ib.Service.Name),
-				Port: (*gatewayv1.PortNumber)(&ib.Service.Port.Number),
-			},
-		}, nil
+		if parent != "" && host[i+1:] != parent {
+			return "", false
+		}
+		parent = host[i+1:]
 	}
-	return &gatewayv1.BackendRef{
-		BackendObjectReference: gatewayv1.BackendObjectReference{
-			Group: (*gatewayv1.Group)(ib.Resource.APIGroup),
-			Kind:  (*gatewayv1.Kind)(&ib.Resource.Kind),
-			Name:  gatewayv1.ObjectName(ib.Resource.Name),
-		},
-	}, nil
+	for _, host := range otherHosts {
+		if host == "" || strings.HasSuffix(host, "."+parent) {
+			return "", false
+		}
+	}
+	return "*." + parent, true
 }
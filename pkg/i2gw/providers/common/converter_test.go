@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 func Test_ingresses2GatewaysAndHttpRoutes(t *testing.T) {
@@ -458,3 +459,272 @@ func Test_ingresses2GatewaysAndHttpRoutes(t *testing.T) {
 		})
 	}
 }
+
+func Test_ToGatewaySources(t *testing.T) {
+	iPrefix := networkingv1.PathTypePrefix
+	newIngress := func(name string) networkingv1.Ingress {
+		return networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: PtrTo("nginx"),
+				Rules: []networkingv1.IngressRule{{
+					Host: "example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{{
+								Path:     "/" + name,
+								PathType: &iPrefix,
+								Backend: networkingv1.IngressBackend{
+									Service: &networkingv1.IngressServiceBackend{
+										Name: name,
+										Port: networkingv1.ServiceBackendPort{Number: 80},
+									},
+								},
+							}},
+						},
+					},
+				}},
+			},
+		}
+	}
+
+	gatewayResources, errs := ToGateway([]networkingv1.Ingress{newIngress("web"), newIngress("api")}, i2gw.ProviderImplementationSpecificOptions{})
+	if len(errs) > 0 {
+		t.Fatalf("Expected no errors but got %v", errs)
+	}
+
+	webRef := i2gw.ObjectRef{GroupVersionKind: IngressGVK, NamespacedName: types.NamespacedName{Namespace: "default", Name: "web"}}
+	apiRef := i2gw.ObjectRef{GroupVersionKind: IngressGVK, NamespacedName: types.NamespacedName{Namespace: "default", Name: "api"}}
+	expectedSources := i2gw.Sources{
+		{GroupVersionKind: GatewayGVK, NamespacedName: types.NamespacedName{Namespace: "default", Name: "nginx"}}:             {webRef, apiRef},
+		{GroupVersionKind: HTTPRouteGVK, NamespacedName: types.NamespacedName{Namespace: "default", Name: "web-example-com"}}: {webRef, apiRef},
+	}
+	if diff := cmp.Diff(expectedSources, gatewayResources.Sources); diff != "" {
+		t.Errorf("Unexpected sources (-want +got):\n%s", diff)
+	}
+}
+
+func Test_ToGatewayListenerConsolidation(t *testing.T) {
+	iPrefix := networkingv1.PathTypePrefix
+	// newIngress returns an Ingress of the host, with TLS when secretName is
+	// not empty.
+	newIngress := func(host, secretName string) networkingv1.Ingress {
+		ingress := networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: NameFromHost(host), Namespace: "default"},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: PtrTo("nginx"),
+				Rules: []networkingv1.IngressRule{{
+					Host: host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{{
+								Path:     "/",
+								PathType: &iPrefix,
+								Backend: networkingv1.IngressBackend{
+									Service: &networkingv1.IngressServiceBackend{
+										Name: "web",
+										Port: networkingv1.ServiceBackendPort{Number: 80},
+									},
+								},
+							}},
+						},
+					},
+				}},
+			},
+		}
+		if secretName != "" {
+			ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: []string{host}, SecretName: secretName}}
+		}
+		return ingress
+	}
+	ingresses := []networkingv1.Ingress{
+		newIngress("foo.example.com", "example-com-cert"),
+		newIngress("bar.example.com", "example-com-cert"),
+		newIngress("shop.example.net", "shop-cert"),
+		newIngress("blog.example.org", "blog-cert"),
+		newIngress("api.example.org", "api-cert"),
+		newIngress("plain.example.org", ""),
+	}
+
+	httpListener := gatewayv1.Listener{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType}
+	testCases := []struct {
+		name                 string
+		consolidation        i2gw.ListenerConsolidation
+		expectedListeners    []gatewayv1.Listener
+		expectedSectionNames map[string][]gatewayv1.SectionName
+	}{
+		{
+			name:          "wildcard",
+			consolidation: i2gw.WildcardListenerConsolidation,
+			expectedListeners: []gatewayv1.Listener{
+				httpListener,
+				{
+					Name:     "wildcard-example-com-https",
+					Hostname: PtrTo(gatewayv1.Hostname("*.example.com")),
+					Port:     443,
+					Protocol: gatewayv1.HTTPSProtocolType,
+					TLS:      &gatewayv1.GatewayTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "example-com-cert"}}},
+				},
+				{
+					Name:     "wildcard-example-net-https",
+					Hostname: PtrTo(gatewayv1.Hostname("*.example.net")),
+					Port:     443,
+					Protocol: gatewayv1.HTTPSProtocolType,
+					TLS:      &gatewayv1.GatewayTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "shop-cert"}}},
+				},
+				// The hosts of example.org are served with different
+				// certificates.
+				{
+					Name:     "https",
+					Port:     443,
+					Protocol: gatewayv1.HTTPSProtocolType,
+					TLS:      &gatewayv1.GatewayTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "api-cert"}, {Name: "blog-cert"}}},
+				},
+			},
+			expectedSectionNames: map[string][]gatewayv1.SectionName{
+				"foo-example-com-foo-example-com":     {"http", "wildcard-example-com-https"},
+				"bar-example-com-bar-example-com":     {"http", "wildcard-example-com-https"},
+				"shop-example-net-shop-example-net":   {"http", "wildcard-example-net-https"},
+				"blog-example-org-blog-example-org":   {"http", "https"},
+				"api-example-org-api-example-org":     {"http", "https"},
+				"plain-example-org-plain-example-org": {"http"},
+			},
+		},
+		{
+			name:          "hostnameless",
+			consolidation: i2gw.HostnamelessListenerConsolidation,
+			expectedListeners: []gatewayv1.Listener{
+				httpListener,
+				{
+					Name:     "https",
+					Port:     443,
+					Protocol: gatewayv1.HTTPSProtocolType,
+					TLS: &gatewayv1.GatewayTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{
+						{Name: "api-cert"}, {Name: "blog-cert"}, {Name: "example-com-cert"}, {Name: "shop-cert"},
+					}},
+				},
+			},
+			expectedSectionNames: map[string][]gatewayv1.SectionName{
+				"foo-example-com-foo-example-com":     {"http", "https"},
+				"bar-example-com-bar-example-com":     {"http", "https"},
+				"shop-example-net-shop-example-net":   {"http", "https"},
+				"blog-example-org-blog-example-org":   {"http", "https"},
+				"api-example-org-api-example-org":     {"http", "https"},
+				"plain-example-org-plain-example-org": {"http"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			gatewayResources, errs := ToGateway(ingresses, i2gw.ProviderImplementationSpecificOptions{ListenerConsolidation: tc.consolidation})
+			if len(errs) > 0 {
+				t.Fatalf("Expected no errors but got %v", errs)
+			}
+
+			gateway := gatewayResources.Gateways[types.NamespacedName{Namespace: "default", Name: "nginx"}]
+			if diff := cmp.Diff(tc.expectedListeners, gateway.Spec.Listeners); diff != "" {
+				t.Errorf("Unexpected listeners (-want +got):\n%s", diff)
+			}
+
+			sectionNames := map[string][]gatewayv1.SectionName{}
+			for _, httpRoute := range gatewayResources.HTTPRoutes {
+				for _, parentRef := range httpRoute.Spec.ParentRefs {
+					if parentRef.Name != "nginx" || parentRef.SectionName == nil {
+						t.Errorf("Expected HTTPRoute %s to attach to a listener of Gateway nginx, got %+v", httpRoute.Name, parentRef)
+						continue
+					}
+					sectionNames[httpRoute.Name] = append(sectionNames[httpRoute.Name], *parentRef.SectionName)
+				}
+			}
+			if diff := cmp.Diff(tc.expectedSectionNames, sectionNames); diff != "" {
+				t.Errorf("Unexpected sectionNames by HTTPRoute (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ToGatewayTLSSecretReferenceGrants(t *testing.T) {
+	iPrefix := networkingv1.PathTypePrefix
+	newIngress := func(namespace, name, host, secretName string) networkingv1.Ingress {
+		return networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: PtrTo("nginx"),
+				TLS:              []networkingv1.IngressTLS{{Hosts: []string{host}, SecretName: secretName}},
+				Rules: []networkingv1.IngressRule{{
+					Host: host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{{
+								Path:     "/",
+								PathType: &iPrefix,
+								Backend: networkingv1.IngressBackend{
+									Service: &networkingv1.IngressServiceBackend{
+										Name: name,
+										Port: networkingv1.ServiceBackendPort{Number: 80},
+									},
+								},
+							}},
+						},
+					},
+				}},
+			},
+		}
+	}
+	ingresses := []networkingv1.Ingress{
+		newIngress("team-a", "web", "web.example.com", "web-cert"),
+		newIngress("team-a", "api", "api.example.com", "api-cert"),
+		newIngress("team-b", "shop", "shop.example.com", "shop-cert"),
+		newIngress("gateway-system", "status", "status.example.com", "status-cert"),
+	}
+
+	gatewayResources, errs := ToGateway(ingresses, i2gw.ProviderImplementationSpecificOptions{GatewayNamespace: "gateway-system"})
+	if len(errs) > 0 {
+		t.Fatalf("Expected no errors but got %v", errs)
+	}
+
+	gateway := gatewayResources.Gateways[types.NamespacedName{Namespace: "team-a", Name: "nginx"}]
+	var certificateRefs []gatewayv1.SecretObjectReference
+	for _, listener := range gateway.Spec.Listeners {
+		if listener.TLS != nil {
+			certificateRefs = append(certificateRefs, listener.TLS.CertificateRefs...)
+		}
+	}
+	expectedCertificateRefs := []gatewayv1.SecretObjectReference{
+		{Name: "api-cert", Namespace: PtrTo[gatewayv1.Namespace]("team-a")},
+		{Name: "web-cert", Namespace: PtrTo[gatewayv1.Namespace]("team-a")},
+	}
+	if diff := cmp.Diff(expectedCertificateRefs, certificateRefs); diff != "" {
+		t.Errorf("Unexpected certificateRefs (-want +got):\n%s", diff)
+	}
+
+	// A single ReferenceGrant per Secret namespace other than the Gateway
+	// namespace.
+	from := []gatewayv1beta1.ReferenceGrantFrom{{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: "gateway-system"}}
+	expectedGrants := map[types.NamespacedName]gatewayv1beta1.ReferenceGrantSpec{
+		{Namespace: "team-a", Name: i2gw.TLSSecretsReferenceGrantName}: {
+			From: from,
+			To: []gatewayv1beta1.ReferenceGrantTo{
+				{Kind: "Secret", Name: PtrTo[gatewayv1.ObjectName]("api-cert")},
+				{Kind: "Secret", Name: PtrTo[gatewayv1.ObjectName]("web-cert")},
+			},
+		},
+		{Namespace: "team-b", Name: i2gw.TLSSecretsReferenceGrantName}: {
+			From: from,
+			To:   []gatewayv1beta1.ReferenceGrantTo{{Kind: "Secret", Name: PtrTo[gatewayv1.ObjectName]("shop-cert")}},
+		},
+	}
+	grants := map[types.NamespacedName]gatewayv1beta1.ReferenceGrantSpec{}
+	for nn, grant := range gatewayResources.ReferenceGrants {
+		grant := grant
+		grants[nn] = grant.Spec
+		if len(gatewayResources.Sources[i2gw.ObjectRefFor(&grant)]) == 0 {
+			t.Errorf("Expected ReferenceGrant %s to have sources", nn)
+		}
+	}
+	if diff := cmp.Diff(expectedGrants, grants); diff != "" {
+		t.Errorf("Unexpected ReferenceGrants (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+)
+
+// StdinInputFile is the input file name used to read the manifests from stdin.
+const StdinInputFile = "-"
+
+// manifestExtensions are the extensions of the files read from input directories.
+var manifestExtensions = []string{".yaml", ".yml", ".json"}
+
+// ReadInputFiles reads the given input files into inputs that can be passed to
+// the providers. Every input is either a file, a directory, whose *.yaml, *.yml
+// and *.json files are read recursively, or "-" to read from stdin.
+//
+// Every file is read into its own input, as is, so that providers reading non
+// Kubernetes documents, such as OpenAPI specs, get them whole.
+func ReadInputFiles(inputs []string, stdin io.Reader) ([]i2gw.Input, error) {
+	files, err := expandInputFiles(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]i2gw.Input, 0, len(files))
+	for _, file := range files {
+		var data []byte
+		if file == StdinInputFile {
+			data, err = io.ReadAll(stdin)
+			file = "stdin"
+		} else {
+			data, err = os.ReadFile(file)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %v: %w", file, err)
+		}
+		result = append(result, i2gw.Input{Name: file, Data: data})
+	}
+	return result, nil
+}
+
+// expandInputFiles replaces the directories among the inputs by the manifest
+// files they contain, recursively and in lexical order.
+func expandInputFiles(inputs []string) ([]string, error) {
+	var files []string
+	for _, input := range inputs {
+		if input == StdinInputFile {
+			files = append(files, input)
+			continue
+		}
+
+		info, err := os.Stat(input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read input %v: %w", input, err)
+		}
+		if !info.IsDir() {
+			files = append(files, input)
+			continue
+		}
+
+		err = filepath.WalkDir(input, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && isManifestFile(path) {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read directory %v: %w", input, err)
+		}
+	}
+	return files, nil
+}
+
+func isManifestFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, manifestExt := range manifestExtensions {
+		if ext == manifestExt {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func ingressManifest(name string) string {
+	return `apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: ` + name + `
+  namespace: default
+spec:
+  ingressClassName: nginx
+`
+}
+
+func TestReadInputFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(path, content string) string {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	single := writeFile("single.yaml", ingressManifest("single"))
+	a := writeFile("manifests/a.yaml", ingressManifest("a")+"---\n"+ingressManifest("b"))
+	c := writeFile("manifests/nested/c.json", `{"apiVersion":"networking.k8s.io/v1","kind":"Ingress","metadata":{"name":"c","namespace":"default"},"spec":{"ingressClassName":"nginx"}}`)
+	writeFile("manifests/README.md", "not a manifest")
+
+	testCases := []struct {
+		name              string
+		inputs            []string
+		stdin             string
+		expectedNames     []string
+		expectedIngresses []string
+	}{
+		{
+			name:              "single file",
+			inputs:            []string{single},
+			expectedNames:     []string{single},
+			expectedIngresses: []string{"single"},
+		},
+		{
+			name:              "directory is read recursively",
+			inputs:            []string{filepath.Join(dir, "manifests")},
+			expectedNames:     []string{a, c},
+			expectedIngresses: []string{"a", "b", "c"},
+		},
+		{
+			name:              "files, directories and stdin",
+			inputs:            []string{single, filepath.Join(dir, "manifests"), StdinInputFile},
+			stdin:             ingressManifest("stdin"),
+			expectedNames:     []string{single, a, c, "stdin"},
+			expectedIngresses: []string{"a", "b", "c", "single", "stdin"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inputs, err := ReadInputFiles(tc.inputs, strings.NewReader(tc.stdin))
+			if err != nil {
+				t.Fatalf("Expected no error but got %v", err)
+			}
+
+			var names []string
+			for _, input := range inputs {
+				names = append(names, input.Name)
+			}
+			if diff := cmp.Diff(tc.expectedNames, names); diff != "" {
+				t.Errorf("Unexpected inputs (-want +got):\n%s", diff)
+			}
+
+			ingresses, err := ReadIngressesFromInputs(inputs, "", sets.New("nginx"))
+			if err != nil {
+				t.Fatalf("Expected no error reading the inputs but got %v", err)
+			}
+			var ingressNames []string
+			for key := range ingresses {
+				ingressNames = append(ingressNames, key.Name)
+			}
+			if diff := cmp.Diff(sets.New(tc.expectedIngresses...), sets.New(ingressNames...)); diff != "" {
+				t.Errorf("Unexpected ingresses (-want +got):\n%s", diff)
+			}
+			if _, ok := ingresses[types.NamespacedName{Namespace: "default", Name: tc.expectedIngresses[0]}]; !ok {
+				t.Errorf("Expected ingress %s to keep its namespace", tc.expectedIngresses[0])
+			}
+		})
+	}
+
+	t.Run("missing input", func(t *testing.T) {
+		if _, err := ReadInputFiles([]string{filepath.Join(dir, "missing.yaml")}, strings.NewReader("")); err == nil {
+			t.Errorf("Expected error for a missing input")
+		}
+	})
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ListPageSize is the maximum number of objects requested by every List call
+// of ListAll.
+const ListPageSize = 500
+
+// ListAll lists the objects of the kind of list page by page, following the
+// continue token of every page, and sets them as the items of list. Listing
+// stops as soon as ctx is done, so that large clusters are not read in a
+// single request and an interrupted run does not wait for the whole list.
+func ListAll(ctx context.Context, cl client.Client, list client.ObjectList, opts ...client.ListOption) error {
+	var items []runtime.Object
+	continueToken := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// Every page is listed in a copy of list, so that the items of the
+		// previous pages are not overwritten.
+		page, ok := list.DeepCopyObject().(client.ObjectList)
+		if !ok {
+			return fmt.Errorf("failed to copy %T", list)
+		}
+		pageOpts := append(slices.Clone(opts), client.Limit(ListPageSize), client.Continue(continueToken))
+		if err := cl.List(ctx, page, pageOpts...); err != nil {
+			return err
+		}
+
+		pageItems, err := apimeta.ExtractList(page)
+		if err != nil {
+			return err
+		}
+		items = append(items, pageItems...)
+
+		continueToken = page.GetContinue()
+		if continueToken == "" {
+			return apimeta.SetList(list, items)
+		}
+	}
+}
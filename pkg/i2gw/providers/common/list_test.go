@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// pagingClient returns a client listing the Ingresses in pages of pageSize,
+// with the index of the next Ingress as continue token, and counting the List
+// calls.
+func pagingClient(ingressCount, pageSize int, calls *int) client.Client {
+	var all []networkingv1.Ingress
+	for i := 0; i < ingressCount; i++ {
+		all = append(all, networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("ingress-%d", i)}})
+	}
+
+	return fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(_ context.Context, _ client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			*calls++
+			listOpts := (&client.ListOptions{}).ApplyOptions(opts)
+			if listOpts.Limit != ListPageSize {
+				return fmt.Errorf("expected a limit of %d, got %d", ListPageSize, listOpts.Limit)
+			}
+
+			start := 0
+			if listOpts.Continue != "" {
+				start, _ = strconv.Atoi(listOpts.Continue)
+			}
+			end := min(start+pageSize, len(all))
+
+			ingressList := list.(*networkingv1.IngressList)
+			ingressList.Items = append([]networkingv1.Ingress{}, all[start:end]...)
+			ingressList.Continue = ""
+			if end < len(all) {
+				ingressList.Continue = strconv.Itoa(end)
+			}
+			return nil
+		},
+	}).Build()
+}
+
+func Test_ListAll(t *testing.T) {
+	testCases := []struct {
+		name          string
+		ingressCount  int
+		pageSize      int
+		expectedCalls int
+	}{
+		{
+			name:          "single page",
+			ingressCount:  3,
+			pageSize:      10,
+			expectedCalls: 1,
+		},
+		{
+			name:          "several pages",
+			ingressCount:  7,
+			pageSize:      3,
+			expectedCalls: 3,
+		},
+		{
+			name:          "no objects",
+			ingressCount:  0,
+			pageSize:      3,
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			cl := pagingClient(tc.ingressCount, tc.pageSize, &calls)
+
+			var ingressList networkingv1.IngressList
+			if err := ListAll(context.Background(), cl, &ingressList); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if calls != tc.expectedCalls {
+				t.Errorf("Expected %d List calls, got %d", tc.expectedCalls, calls)
+			}
+			if len(ingressList.Items) != tc.ingressCount {
+				t.Fatalf("Expected %d Ingresses, got %d", tc.ingressCount, len(ingressList.Items))
+			}
+			for i, ingress := range ingressList.Items {
+				if expected := fmt.Sprintf("ingress-%d", i); ingress.Name != expected {
+					t.Errorf("Expected Ingress %d to be %s, got %s", i, expected, ingress.Name)
+				}
+			}
+		})
+	}
+}
+
+func Test_ListAllCancelled(t *testing.T) {
+	calls := 0
+	cl := pagingClient(7, 3, &calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var ingressList networkingv1.IngressList
+	if err := ListAll(ctx, cl, &ingressList); err == nil {
+		t.Fatalf("Expected an error, got none")
+	}
+	if calls != 0 {
+		t.Errorf("Expected no List call, got %d", calls)
+	}
+}
@@ -22,9 +22,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	apiv1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -35,7 +38,7 @@ import (
 
 func ReadIngressesFromCluster(ctx context.Context, client client.Client, ingressClasses sets.Set[string]) (map[types.NamespacedName]*networkingv1.Ingress, error) {
 	var ingressList networkingv1.IngressList
-	err := client.List(ctx, &ingressList)
+	err := ListAll(ctx, client, &ingressList)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get ingresses from the cluster: %w", err)
 	}
@@ -51,15 +54,10 @@ func ReadIngressesFromCluster(ctx context.Context, client client.Client, ingress
 	return ingresses, nil
 }
 
-func ReadIngressesFromFile(filename, namespace string, ingressClasses sets.Set[string]) (map[types.NamespacedName]*networkingv1.Ingress, error) {
-	stream, err := os.ReadFile(filename)
+func ReadIngressesFromInputs(inputs []i2gw.Input, namespace string, ingressClasses sets.Set[string]) (map[types.NamespacedName]*networkingv1.Ingress, error) {
+	unstructuredObjects, err := ExtractObjectsFromInputs(inputs, namespace)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file %v: %w", filename, err)
-	}
-
-	unstructuredObjects, err := ExtractObjectsFromReader(bytes.NewReader(stream), namespace)
-	if err != nil {
-		return nil, fmt.Errorf("failed to extract objects: %w", err)
+		return nil, err
 	}
 
 	ingresses := map[types.NamespacedName]*networkingv1.Ingress{}
@@ -81,6 +79,100 @@ func ReadIngressesFromFile(filename, namespace string, ingressClasses sets.Set[s
 	return ingresses, nil
 }
 
+// ReadServicesFromCluster reads the Services from the cluster. Services are used
+// to resolve Ingress backends referencing a Service port by name. As they are
+// not required by the conversion, a forbidden list is reported as a warning
+// notification, leaving the named ports unresolved, rather than as an error.
+func ReadServicesFromCluster(ctx context.Context, client client.Client) (map[types.NamespacedName]*apiv1.Service, []notifications.Notification, error) {
+	var serviceList apiv1.ServiceList
+	err := ListAll(ctx, client, &serviceList)
+	if err != nil {
+		if apierrors.IsForbidden(err) {
+			return map[types.NamespacedName]*apiv1.Service{}, []notifications.Notification{
+				notifications.NewNotification(notifications.WarningNotification,
+					fmt.Sprintf("unable to read Services from the cluster, named Service ports are left unresolved: %v", err)),
+			}, nil
+		}
+		return nil, nil, fmt.Errorf("failed to get services from the cluster: %w", err)
+	}
+
+	services := map[types.NamespacedName]*apiv1.Service{}
+	for i, service := range serviceList.Items {
+		services[types.NamespacedName{Namespace: service.Namespace, Name: service.Name}] = &serviceList.Items[i]
+	}
+
+	return services, nil, nil
+}
+
+// ReadServicesFromInputs reads the Services from the inputs. Services are used
+// to resolve Ingress backends referencing a Service port by name.
+func ReadServicesFromInputs(inputs []i2gw.Input, namespace string) (map[types.NamespacedName]*apiv1.Service, error) {
+	unstructuredObjects, err := ExtractObjectsFromInputs(inputs, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	services := map[types.NamespacedName]*apiv1.Service{}
+	for _, f := range unstructuredObjects {
+		if !f.GroupVersionKind().Empty() && f.GroupVersionKind().Kind == "Service" {
+			var service apiv1.Service
+			err = runtime.DefaultUnstructuredConverter.
+				FromUnstructured(f.UnstructuredContent(), &service)
+			if err != nil {
+				return nil, err
+			}
+			services[types.NamespacedName{Namespace: service.Namespace, Name: service.Name}] = &service
+		}
+	}
+	return services, nil
+}
+
+// ReadConfigMapsFromCluster reads the ConfigMaps with the given keys from the
+// cluster, skipping the missing ones. ConfigMaps are read by key as providers
+// only need the few ones referenced by annotations. When namespace is set, the
+// client is expected to be scoped to it, and the keys of other namespaces are
+// skipped as well. So are the keys the client is forbidden to get.
+func ReadConfigMapsFromCluster(ctx context.Context, client client.Client, namespace string, keys []types.NamespacedName) (map[types.NamespacedName]*apiv1.ConfigMap, error) {
+	configMaps := map[types.NamespacedName]*apiv1.ConfigMap{}
+	for _, key := range keys {
+		if namespace != "" && key.Namespace != namespace {
+			continue
+		}
+		var configMap apiv1.ConfigMap
+		if err := client.Get(ctx, key, &configMap); err != nil {
+			if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get configmap %s from the cluster: %w", key, err)
+		}
+		configMaps[key] = &configMap
+	}
+
+	return configMaps, nil
+}
+
+// ReadConfigMapsFromInputs reads the ConfigMaps from the inputs.
+func ReadConfigMapsFromInputs(inputs []i2gw.Input, namespace string) (map[types.NamespacedName]*apiv1.ConfigMap, error) {
+	unstructuredObjects, err := ExtractObjectsFromInputs(inputs, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	configMaps := map[types.NamespacedName]*apiv1.ConfigMap{}
+	for _, f := range unstructuredObjects {
+		if !f.GroupVersionKind().Empty() && f.GroupVersionKind().Kind == "ConfigMap" {
+			var configMap apiv1.ConfigMap
+			err = runtime.DefaultUnstructuredConverter.
+				FromUnstructured(f.UnstructuredContent(), &configMap)
+			if err != nil {
+				return nil, err
+			}
+			configMaps[types.NamespacedName{Namespace: configMap.Namespace, Name: configMap.Name}] = &configMap
+		}
+	}
+	return configMaps, nil
+}
+
 // ExtractObjectsFromReader extracts all objects from a reader,
 // which is created from YAML or JSON input files.
 // It retrieves all objects, including nested ones if they are contained within a list.
@@ -105,6 +197,43 @@ func ExtractObjectsFromReader(reader io.Reader, namespace string) ([]*unstructur
 		objs = append(objs, u)
 	}
 
+	return expandLists(objs)
+}
+
+// ExtractObjectsFromInputs extracts all objects from the inputs, decoding the
+// ones not given as objects. As ExtractObjectsFromReader, it expands lists,
+// and only returns the resources of namespace when it is set. The objects given
+// as such are copied, so that the inputs can be read by several providers.
+func ExtractObjectsFromInputs(inputs []i2gw.Input, namespace string) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	for _, input := range inputs {
+		if input.Objects == nil {
+			inputObjs, err := ExtractObjectsFromReader(bytes.NewReader(input.Data), namespace)
+			if err != nil {
+				return nil, fmt.Errorf("failed to extract objects from %v: %w", input.Name, err)
+			}
+			objs = append(objs, inputObjs...)
+			continue
+		}
+
+		var inputObjs []*unstructured.Unstructured
+		for _, obj := range input.Objects {
+			if namespace != "" && obj.GetNamespace() != namespace {
+				continue
+			}
+			inputObjs = append(inputObjs, obj.DeepCopy())
+		}
+		inputObjs, err := expandLists(inputObjs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract objects from %v: %w", input.Name, err)
+		}
+		objs = append(objs, inputObjs...)
+	}
+	return objs, nil
+}
+
+// expandLists replaces the lists among the objects by their items.
+func expandLists(objs []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
 	finalObjs := []*unstructured.Unstructured{}
 	for _, obj := range objs {
 		tmpObjs := []*unstructured.Unstructured{}
@@ -18,16 +18,24 @@ package common
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	networkingv1 "k8s.io/api/networking/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 func Test_ExtractObjectsFromReader(t *testing.T) {
@@ -142,3 +150,22 @@ func compareIngressLists(t *testing.T, gotIngressList *networkingv1.IngressList,
 		}
 	}
 }
+
+func Test_ReadServicesFromClusterForbidden(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(_ context.Context, _ client.WithWatch, _ client.ObjectList, _ ...client.ListOption) error {
+			return apierrors.NewForbidden(schema.GroupResource{Resource: "services"}, "", fmt.Errorf("no RBAC"))
+		},
+	}).Build()
+
+	services, gotNotifications, err := ReadServicesFromCluster(context.Background(), cl)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(services) != 0 {
+		t.Errorf("Expected no Services, got %d", len(services))
+	}
+	if len(gotNotifications) != 1 || gotNotifications[0].Type != notifications.WarningNotification {
+		t.Errorf("Expected a single warning notification, got %+v", gotNotifications)
+	}
+}
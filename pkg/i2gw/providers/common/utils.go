@@ -20,8 +20,11 @@ import (
 	"fmt"
 	"regexp"
 
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	apiv1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
@@ -105,11 +108,14 @@ func RouteName(ingressName, host string) string {
 	return fmt.Sprintf("%s-%s", ingressName, NameFromHost(host))
 }
 
+// ToBackendRef converts the IngressBackend to a BackendRef. Named Service ports
+// are expected to be resolved beforehand through ResolveNamedServicePorts: a
+// port that is still referenced by name results in no BackendRef, as Gateway
+// API requires the port of Service references.
 func ToBackendRef(ib networkingv1.IngressBackend, path *field.Path) (*gatewayv1.BackendRef, *field.Error) {
 	if ib.Service != nil {
 		if ib.Service.Port.Name != "" {
-			fieldPath := path.Child("service", "port")
-			return nil, field.Invalid(fieldPath, "name", fmt.Sprintf("named ports not supported: %s", ib.Service.Port.Name))
+			return nil, nil
 		}
 		return &gatewayv1.BackendRef{
 			BackendObjectReference: gatewayv1.BackendObjectReference{
@@ -127,6 +133,70 @@ func ToBackendRef(ib networkingv1.IngressBackend, path *field.Path) (*gatewayv1.
 	}, nil
 }
 
+// GroupServicePortsByPortName returns the port numbers of every Service port,
+// by Service and port name.
+func GroupServicePortsByPortName(services map[types.NamespacedName]*apiv1.Service) map[types.NamespacedName]map[string]int32 {
+	servicePorts := map[types.NamespacedName]map[string]int32{}
+	for key, service := range services {
+		ports := map[string]int32{}
+		for _, port := range service.Spec.Ports {
+			if port.Name != "" {
+				ports[port.Name] = port.Port
+			}
+		}
+		servicePorts[key] = ports
+	}
+	return servicePorts
+}
+
+// ResolveNamedServicePorts returns a copy of the ingresses where every backend
+// referencing a Service port by name references it by number instead, using the
+// given port numbers by Service and port name (see GroupServicePortsByPortName).
+// Ports that cannot be resolved are left untouched and reported as error
+// notifications: their backends are dropped by ToBackendRef, so that the
+// conversion can carry on without them.
+func ResolveNamedServicePorts(ingresses []networkingv1.Ingress, servicePorts map[types.NamespacedName]map[string]int32) ([]networkingv1.Ingress, []notifications.Notification) {
+	var notificationsAggregator []notifications.Notification
+
+	resolvedIngresses := make([]networkingv1.Ingress, 0, len(ingresses))
+	for _, ingress := range ingresses {
+		ingress := ingress.DeepCopy()
+
+		resolve := func(ib *networkingv1.IngressBackend) {
+			if ib == nil || ib.Service == nil || ib.Service.Port.Name == "" {
+				return
+			}
+			serviceKey := types.NamespacedName{Namespace: ingress.Namespace, Name: ib.Service.Name}
+			ports, ok := servicePorts[serviceKey]
+			if !ok {
+				notificationsAggregator = append(notificationsAggregator, notifications.NewNotification(notifications.ErrorNotification,
+					fmt.Sprintf("unable to resolve named port %q: Service %s not found, the backendRef is dropped", ib.Service.Port.Name, serviceKey), ingress))
+				return
+			}
+			number, ok := ports[ib.Service.Port.Name]
+			if !ok {
+				notificationsAggregator = append(notificationsAggregator, notifications.NewNotification(notifications.ErrorNotification,
+					fmt.Sprintf("unable to resolve named port %q: Service %s has no such port, the backendRef is dropped", ib.Service.Port.Name, serviceKey), ingress))
+				return
+			}
+			ib.Service.Port = networkingv1.ServiceBackendPort{Number: number}
+		}
+
+		resolve(ingress.Spec.DefaultBackend)
+		for i := range ingress.Spec.Rules {
+			if ingress.Spec.Rules[i].HTTP == nil {
+				continue
+			}
+			for j := range ingress.Spec.Rules[i].HTTP.Paths {
+				resolve(&ingress.Spec.Rules[i].HTTP.Paths[j].Backend)
+			}
+		}
+		resolvedIngresses = append(resolvedIngresses, *ingress)
+	}
+
+	return resolvedIngresses, notificationsAggregator
+}
+
 type orderedIngressPathsByMatchKey struct {
 	keys []pathMatchKey
 	data map[pathMatchKey][]ingressPath
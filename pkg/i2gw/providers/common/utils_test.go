@@ -19,8 +19,14 @@ package common
 import (
 	"testing"
 
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 func TestGroupIngressPathsByMatchKey(t *testing.T) {
@@ -530,3 +536,111 @@ func TestGroupIngressPathsByMatchKey(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveNamedServicePorts(t *testing.T) {
+	namedPortIngress := func(serviceName, portName string) networkingv1.Ingress {
+		return networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: "default"},
+			Spec: networkingv1.IngressSpec{
+				DefaultBackend: &networkingv1.IngressBackend{
+					Service: &networkingv1.IngressServiceBackend{
+						Name: serviceName,
+						Port: networkingv1.ServiceBackendPort{Name: portName},
+					},
+				},
+				Rules: []networkingv1.IngressRule{{
+					Host: "example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{{
+								Path:     "/",
+								PathType: PtrTo(networkingv1.PathTypePrefix),
+								Backend: networkingv1.IngressBackend{
+									Service: &networkingv1.IngressServiceBackend{
+										Name: serviceName,
+										Port: networkingv1.ServiceBackendPort{Name: portName},
+									},
+								},
+							}},
+						},
+					},
+				}},
+			},
+		}
+	}
+	numberedPortIngress := func(serviceName string, portNumber int32) networkingv1.Ingress {
+		ingress := namedPortIngress(serviceName, "")
+		ingress.Spec.DefaultBackend.Service.Port.Number = portNumber
+		ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Number = portNumber
+		return ingress
+	}
+
+	servicePorts := GroupServicePortsByPortName(map[types.NamespacedName]*apiv1.Service{
+		{Namespace: "default", Name: "app"}: {
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec: apiv1.ServiceSpec{
+				Ports: []apiv1.ServicePort{
+					{Name: "http", Port: 8080},
+					{Name: "metrics", Port: 9090},
+				},
+			},
+		},
+	})
+
+	testCases := []struct {
+		name                  string
+		ingress               networkingv1.Ingress
+		expectedIngress       networkingv1.Ingress
+		expectedNotifications int
+	}{
+		{
+			name:                  "named port is resolved",
+			ingress:               namedPortIngress("app", "http"),
+			expectedIngress:       numberedPortIngress("app", 8080),
+			expectedNotifications: 0,
+		},
+		{
+			name:                  "numbered port is left untouched",
+			ingress:               numberedPortIngress("app", 80),
+			expectedIngress:       numberedPortIngress("app", 80),
+			expectedNotifications: 0,
+		},
+		{
+			name:                  "service not found",
+			ingress:               namedPortIngress("missing", "http"),
+			expectedIngress:       namedPortIngress("missing", "http"),
+			expectedNotifications: 2,
+		},
+		{
+			name:                  "port name not found",
+			ingress:               namedPortIngress("app", "grpc"),
+			expectedIngress:       namedPortIngress("app", "grpc"),
+			expectedNotifications: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ingresses, notificationsAggregator := ResolveNamedServicePorts([]networkingv1.Ingress{tc.ingress}, servicePorts)
+			require.Equal(t, []networkingv1.Ingress{tc.expectedIngress}, ingresses)
+			require.Len(t, notificationsAggregator, tc.expectedNotifications)
+			for _, n := range notificationsAggregator {
+				require.Equal(t, notifications.ErrorNotification, n.Type)
+			}
+		})
+	}
+}
+
+func TestToBackendRefNamedPort(t *testing.T) {
+	backendRef, err := ToBackendRef(networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{Name: "app", Port: networkingv1.ServiceBackendPort{Name: "http"}},
+	}, field.NewPath("backend"))
+	require.Nil(t, err)
+	require.Nil(t, backendRef, "a Service reference without port cannot be applied")
+
+	backendRef, err = ToBackendRef(networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{Name: "app", Port: networkingv1.ServiceBackendPort{Number: 8080}},
+	}, field.NewPath("backend"))
+	require.Nil(t, err)
+	require.Equal(t, PtrTo(gatewayv1.PortNumber(8080)), backendRef.Port)
+}
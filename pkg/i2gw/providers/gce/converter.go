@@ -22,6 +22,7 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 // converter implements the ToGatewayAPI function of i2gw.ResourceConverter interface.
@@ -38,7 +39,11 @@ func newConverter(conf *i2gw.ProviderConf) converter {
 		conf:           conf,
 		featureParsers: []i2gw.FeatureParser{},
 		implementationSpecificOptions: i2gw.ProviderImplementationSpecificOptions{
-			ToImplementationSpecificHTTPPathTypeMatch: implementationSpecificHTTPPathTypeMatch,
+			ToImplementationSpecificHTTPPathTypeMatch: func(path *gatewayv1.HTTPPathMatch) {
+				implementationSpecificHTTPPathTypeMatch(path, conf.NotificationAggregator())
+			},
+			ListenerConsolidation: conf.ListenerConsolidation,
+			GatewayNamespace:      conf.GatewayNamespace,
 		},
 	}
 }
@@ -55,6 +60,9 @@ func (c *converter) convert(storage *storage) (i2gw.GatewayResources, field.Erro
 		ingressList = append(ingressList, *ing)
 	}
 
+	ingressList, notificationsAggregator := common.ResolveNamedServicePorts(ingressList, storage.ServicePorts)
+	dispatchNotification(c.conf.NotificationAggregator(), notificationsAggregator)
+
 	// Convert plain ingress resources to gateway resources, ignoring all
 	// provider-specific features.
 	gatewayResources, errs := common.ToGateway(ingressList, c.implementationSpecificOptions)
@@ -69,7 +77,7 @@ func (c *converter) convert(storage *storage) (i2gw.GatewayResources, field.Erro
 
 	for _, parseFeatureFunc := range c.featureParsers {
 		// Apply the feature parsing function to the gateway resources, one by one.
-		parseErrs := parseFeatureFunc(ingressList, &gatewayResources)
+		parseErrs := parseFeatureFunc(ingressList, &gatewayResources, c.conf.NotificationAggregator())
 		// Append the parsing errors to the error list.
 		errs = append(errs, parseErrs...)
 	}
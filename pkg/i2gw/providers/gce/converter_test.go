@@ -23,6 +23,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
 	networkingv1 "k8s.io/api/networking/v1"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
@@ -567,7 +568,7 @@ func Test_ToGateway(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 
-			provider := NewProvider(&i2gw.ProviderConf{})
+			provider := NewProvider(&i2gw.ProviderConf{Notifications: notifications.NewNotificationAggregator()})
 			gceProvider := provider.(*Provider)
 			gceProvider.storage = newResourcesStorage()
 			gceProvider.storage.Ingresses = tc.ingresses
@@ -55,10 +55,10 @@ func (p *Provider) ReadResourcesFromCluster(ctx context.Context) error {
 	return nil
 }
 
-func (p *Provider) ReadResourcesFromFile(_ context.Context, filename string) error {
-	storage, err := p.reader.readResourcesFromFile(filename)
+func (p *Provider) ReadResourcesFromInputs(_ context.Context, inputs []i2gw.Input) error {
+	storage, err := p.reader.readResourcesFromInputs(inputs)
 	if err != nil {
-		return fmt.Errorf("failed to read gce resources from file: %w", err)
+		return fmt.Errorf("failed to read gce resources from the inputs: %w", err)
 	}
 	p.storage = storage
 	return nil
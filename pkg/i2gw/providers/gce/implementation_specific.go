@@ -39,7 +39,7 @@ import (
 // | /v1                                   | /v1 Exact                              |
 // | /v1/                                  | /v1/ Exact                             |
 // | /v1/*                                 | /v1 Prefix                             |
-func implementationSpecificHTTPPathTypeMatch(path *gatewayv1.HTTPPathMatch) {
+func implementationSpecificHTTPPathTypeMatch(path *gatewayv1.HTTPPathMatch, notificationAggr *notifications.NotificationAggregator) {
 	pmExact := gatewayv1.PathMatchExact
 	pmPrefix := gatewayv1.PathMatchPathPrefix
 
@@ -56,6 +56,6 @@ func implementationSpecificHTTPPathTypeMatch(path *gatewayv1.HTTPPathMatch) {
 	currentValue := *path.Value
 	path.Type = &pmPrefix
 	path.Value = common.PtrTo(strings.TrimSuffix(*path.Value, "/*"))
-	notify(notifications.WarningNotification, fmt.Sprintf("After conversion, ImplementationSpecific Path %s/* will additionally map to %s. See https://github.com/kubernetes-sigs/ingress2gateway/blob/main/pkg/i2gw/providers/gce/README.md for details.", currentValue, *path.Value))
+	notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("After conversion, ImplementationSpecific Path %s/* will additionally map to %s. See https://github.com/kubernetes-sigs/ingress2gateway/blob/main/pkg/i2gw/providers/gce/README.md for details.", currentValue, *path.Value))
 	klog.Warningf("After conversion, ImplementationSpecific Path %s/* will additionally map to %s. See https://github.com/kubernetes-sigs/ingress2gateway/blob/main/pkg/i2gw/providers/gce/README.md for details.", currentValue, *path.Value)
 }
@@ -21,7 +21,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func notify(mType notifications.MessageType, message string, callingObject ...client.Object) {
-	newNotification := notifications.Notification{Type: mType, Message: message, CallingObjects: callingObject}
-	notifications.NotificationAggr.DispatchNotification(newNotification, string(ProviderName))
+func notify(notificationAggr *notifications.NotificationAggregator, mType notifications.MessageType, message string, callingObject ...client.Object) {
+	newNotification := notifications.NewNotification(mType, message, callingObject...)
+	notificationAggr.DispatchNotification(newNotification, string(ProviderName))
+}
+
+func dispatchNotification(notificationAggr *notifications.NotificationAggregator, n []notifications.Notification) {
+	for _, v := range n {
+		notificationAggr.DispatchNotification(v, string(ProviderName))
+	}
 }
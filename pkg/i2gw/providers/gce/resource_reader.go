@@ -50,16 +50,29 @@ func (r *reader) readResourcesFromCluster(ctx context.Context) (*storage, error)
 		return nil, err
 	}
 	storage.Ingresses = (ingresses)
+
+	services, serviceNotifications, err := common.ReadServicesFromCluster(ctx, r.conf.Client)
+	if err != nil {
+		return nil, err
+	}
+	dispatchNotification(r.conf.NotificationAggregator(), serviceNotifications)
+	storage.ServicePorts = common.GroupServicePortsByPortName(services)
 	return storage, nil
 }
 
-func (r *reader) readResourcesFromFile(filename string) (*storage, error) {
+func (r *reader) readResourcesFromInputs(inputs []i2gw.Input) (*storage, error) {
 	storage := newResourcesStorage()
 
-	ingresses, err := common.ReadIngressesFromFile(filename, r.conf.Namespace, supportedGCEIngressClass)
+	ingresses, err := common.ReadIngressesFromInputs(inputs, r.conf.Namespace, supportedGCEIngressClass)
 	if err != nil {
 		return nil, err
 	}
 	storage.Ingresses = ingresses
+
+	services, err := common.ReadServicesFromInputs(inputs, r.conf.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	storage.ServicePorts = common.GroupServicePortsByPortName(services)
 	return storage, nil
 }
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressnginx
+
+import "fmt"
+
+const (
+	annotationPrefix = "nginx.ingress.kubernetes.io"
+
+	useRegexKey         = "use-regex"
+	rewriteTargetKey    = "rewrite-target"
+	sslRedirectKey      = "ssl-redirect"
+	forceSSLRedirectKey = "force-ssl-redirect"
+
+	permanentRedirectKey     = "permanent-redirect"
+	permanentRedirectCodeKey = "permanent-redirect-code"
+	temporalRedirectKey      = "temporal-redirect"
+	appRootKey               = "app-root"
+
+	customHeadersKey    = "custom-headers"
+	proxySetHeadersKey  = "proxy-set-headers"
+	upstreamVhostKey    = "upstream-vhost"
+	xForwardedPrefixKey = "x-forwarded-prefix"
+
+	proxyConnectTimeoutKey = "proxy-connect-timeout"
+	proxySendTimeoutKey    = "proxy-send-timeout"
+	proxyReadTimeoutKey    = "proxy-read-timeout"
+	proxyBodySizeKey       = "proxy-body-size"
+	proxyBufferingKey      = "proxy-buffering"
+)
+
+func nginxAnnotation(suffix string) string {
+	return fmt.Sprintf("%s/%s", annotationPrefix, suffix)
+}
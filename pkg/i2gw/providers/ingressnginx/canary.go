@@ -30,7 +30,7 @@ import (
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
-func canaryFeature(ingresses []networkingv1.Ingress, gatewayResources *i2gw.GatewayResources) field.ErrorList {
+func canaryFeature(ingresses []networkingv1.Ingress, gatewayResources *i2gw.GatewayResources, notificationAggr *notifications.NotificationAggregator) field.ErrorList {
 	ruleGroups := common.GetRuleGroups(ingresses)
 
 	for _, rg := range ruleGroups {
@@ -66,7 +66,7 @@ func canaryFeature(ingresses []networkingv1.Ingress, gatewayResources *i2gw.Gate
 					continue
 				}
 
-				patchHTTPRouteWithBackendRefs(&httpRoute, backendRefs)
+				patchHTTPRouteWithBackendRefs(&httpRoute, backendRefs, notificationAggr)
 			}
 			if len(errs) > 0 {
 				return errs
@@ -100,7 +100,7 @@ func getPathsByMatchGroups(rg common.IngressRuleGroup) (map[pathMatchKey][]ingre
 	return ingressPathsByMatchKey, nil
 }
 
-func patchHTTPRouteWithBackendRefs(httpRoute *gatewayv1.HTTPRoute, backendRefs []gatewayv1.HTTPBackendRef) {
+func patchHTTPRouteWithBackendRefs(httpRoute *gatewayv1.HTTPRoute, backendRefs []gatewayv1.HTTPBackendRef, notificationAggr *notifications.NotificationAggregator) {
 	var ruleExists bool
 	for _, backendRef := range backendRefs {
 
@@ -123,7 +123,8 @@ func patchHTTPRouteWithBackendRefs(httpRoute *gatewayv1.HTTPRoute, backendRefs [
 		}
 	}
 	if ruleExists {
-		notify(notifications.InfoNotification, fmt.Sprintf("parsed canary annotations of ingress and patched %v fields", field.NewPath("httproute", "spec", "rules").Key("").Child("backendRefs")), httpRoute)
+		fieldPath := field.NewPath("httproute", "spec", "rules").Key("").Child("backendRefs")
+		notifyField(notificationAggr, notifications.InfoNotification, fieldPath, fmt.Sprintf("parsed canary annotations of ingress and patched %v fields", fieldPath), httpRoute)
 	}
 }
 
@@ -142,6 +143,9 @@ func calculateBackendRefWeight(paths []ingressPath) ([]gatewayv1.HTTPBackendRef,
 			errors = append(errors, err)
 			continue
 		}
+		if backendRef == nil {
+			continue
+		}
 		if path.extra != nil && path.extra.canary != nil && path.extra.canary.enable {
 			weight := int32(path.extra.canary.weight)
 			backendRef.Weight = &weight
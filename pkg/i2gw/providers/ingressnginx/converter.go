@@ -19,38 +19,74 @@ package ingressnginx
 import (
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // converter implements the ToGatewayAPI function of i2gw.ResourceConverter interface.
 type converter struct {
+	conf *i2gw.ProviderConf
+
 	featureParsers []i2gw.FeatureParser
+
+	// configMaps are the ConfigMaps referenced by the annotations of the
+	// ingresses being converted.
+	configMaps map[types.NamespacedName]*apiv1.ConfigMap
+
+	// policyEmitter emits the policies carrying the annotations without core
+	// Gateway API equivalent, nil when no policyEmitterName is selected with
+	// the PolicyEmitterFlag or when it is not supported.
+	policyEmitter     policyEmitter
+	policyEmitterName string
 }
 
 // newConverter returns an ingress-nginx converter instance.
-func newConverter() *converter {
-	return &converter{
-		featureParsers: []i2gw.FeatureParser{
-			canaryFeature,
-		},
+func newConverter(conf *i2gw.ProviderConf) *converter {
+	c := &converter{
+		conf:              conf,
+		policyEmitterName: conf.ProviderSpecificFlags[Name][PolicyEmitterFlag],
 	}
+	c.policyEmitter = policyEmitters[c.policyEmitterName]
+	c.featureParsers = []i2gw.FeatureParser{
+		canaryFeature,
+		rewriteFeature,
+		redirectFeature,
+		c.headersFeature,
+		// sslRedirectFeature copies the rules patched by the features above.
+		sslRedirectFeature,
+		// proxyFeature patches the HTTPRoutes split by sslRedirectFeature.
+		c.proxyFeature,
+	}
+	return c
 }
 
 func (c *converter) convert(storage *storage) (i2gw.GatewayResources, field.ErrorList) {
+	if c.policyEmitterName != "" && c.policyEmitter == nil {
+		return i2gw.GatewayResources{}, field.ErrorList{field.NotSupported(field.NewPath(PolicyEmitterFlag), c.policyEmitterName, policyEmitterNames())}
+	}
 
 	// TODO(liorliberman) temporary until we decide to change ToGateway and featureParsers to get a map of [types.NamespacedName]*networkingv1.Ingress instead of a list
 	ingressList := storage.Ingresses.List()
+	c.configMaps = storage.ConfigMaps
+
+	ingressList, notificationsAggregator := common.ResolveNamedServicePorts(ingressList, storage.ServicePorts)
+	dispatchNotification(c.conf.NotificationAggregator(), notificationsAggregator)
 
 	// Convert plain ingress resources to gateway resources, ignoring all
 	// provider-specific features.
-	gatewayResources, errs := common.ToGateway(ingressList, i2gw.ProviderImplementationSpecificOptions{})
+	gatewayResources, errs := common.ToGateway(ingressList, i2gw.ProviderImplementationSpecificOptions{
+		ToImplementationSpecificHTTPPathTypeMatch: implementationSpecificHTTPPathTypeMatch,
+		ListenerConsolidation:                     c.conf.ListenerConsolidation,
+		GatewayNamespace:                          c.conf.GatewayNamespace,
+	})
 	if len(errs) > 0 {
 		return i2gw.GatewayResources{}, errs
 	}
 
 	for _, parseFeatureFunc := range c.featureParsers {
 		// Apply the feature parsing function to the gateway resources, one by one.
-		parseErrs := parseFeatureFunc(ingressList, &gatewayResources)
+		parseErrs := parseFeatureFunc(ingressList, &gatewayResources, c.conf.NotificationAggregator())
 		// Append the parsing errors to the error list.
 		errs = append(errs, parseErrs...)
 	}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -29,7 +30,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
-	"k8s.io/utils/ptr"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
@@ -204,15 +204,54 @@ func Test_ToGateway(t *testing.T) {
 					},
 				},
 			},
-			expectedGatewayResources: i2gw.GatewayResources{},
-			expectedErrors: field.ErrorList{
-				{
-					Type:     field.ErrorTypeInvalid,
-					Field:    "spec.rules[0].http.paths[0].pathType",
-					BadValue: ptr.To("ImplementationSpecific"),
-					Detail:   "implementationSpecific path type is not supported in generic translation, and your provider does not provide custom support to translate it",
+			expectedGatewayResources: i2gw.GatewayResources{
+				Gateways: map[types.NamespacedName]gatewayv1.Gateway{
+					{Namespace: "default", Name: "ingress-nginx"}: {
+						ObjectMeta: metav1.ObjectMeta{Name: "ingress-nginx", Namespace: "default"},
+						Spec: gatewayv1.GatewaySpec{
+							GatewayClassName: "ingress-nginx",
+							Listeners: []gatewayv1.Listener{{
+								Name:     "test-mydomain-com-http",
+								Port:     80,
+								Protocol: gatewayv1.HTTPProtocolType,
+								Hostname: ptrTo(gatewayv1.Hostname("test.mydomain.com")),
+							}},
+						},
+					},
+				},
+				HTTPRoutes: map[types.NamespacedName]gatewayv1.HTTPRoute{
+					{Namespace: "default", Name: "implementation-specific-regex-test-mydomain-com"}: {
+						ObjectMeta: metav1.ObjectMeta{Name: "implementation-specific-regex-test-mydomain-com", Namespace: "default"},
+						Spec: gatewayv1.HTTPRouteSpec{
+							CommonRouteSpec: gatewayv1.CommonRouteSpec{
+								ParentRefs: []gatewayv1.ParentReference{{
+									Name: "ingress-nginx",
+								}},
+							},
+							Hostnames: []gatewayv1.Hostname{"test.mydomain.com"},
+							Rules: []gatewayv1.HTTPRouteRule{{
+								Matches: []gatewayv1.HTTPRouteMatch{{
+									Path: &gatewayv1.HTTPPathMatch{
+										Type:  &gPathPrefix,
+										Value: ptrTo("/~/echo/**/test"),
+									},
+								}},
+								BackendRefs: []gatewayv1.HTTPBackendRef{
+									{
+										BackendRef: gatewayv1.BackendRef{
+											BackendObjectReference: gatewayv1.BackendObjectReference{
+												Name: "test",
+												Port: ptrTo(gatewayv1.PortNumber(80)),
+											},
+										},
+									},
+								},
+							}},
+						},
+					},
 				},
 			},
+			expectedErrors: field.ErrorList{},
 		},
 		{
 			name: "multiple rules with TLS",
@@ -335,7 +374,8 @@ func Test_ToGateway(t *testing.T) {
 						Spec: gatewayv1.HTTPRouteSpec{
 							CommonRouteSpec: gatewayv1.CommonRouteSpec{
 								ParentRefs: []gatewayv1.ParentReference{{
-									Name: "nginx",
+									Name:        "nginx",
+									SectionName: ptrTo(gatewayv1.SectionName("bar-example-com-https")),
 								}},
 							},
 							Hostnames: []gatewayv1.Hostname{"bar.example.com"},
@@ -359,12 +399,42 @@ func Test_ToGateway(t *testing.T) {
 							}},
 						},
 					},
+					{Namespace: "default", Name: "example-ingress-bar-example-com-http"}: {
+						ObjectMeta: metav1.ObjectMeta{Name: "example-ingress-bar-example-com-http", Namespace: "default"},
+						Spec: gatewayv1.HTTPRouteSpec{
+							CommonRouteSpec: gatewayv1.CommonRouteSpec{
+								ParentRefs: []gatewayv1.ParentReference{{
+									Name:        "nginx",
+									SectionName: ptrTo(gatewayv1.SectionName("bar-example-com-http")),
+								}},
+							},
+							Hostnames: []gatewayv1.Hostname{"bar.example.com"},
+							Rules: []gatewayv1.HTTPRouteRule{
+								{
+									Matches: []gatewayv1.HTTPRouteMatch{{
+										Path: &gatewayv1.HTTPPathMatch{
+											Type:  &gPathPrefix,
+											Value: ptrTo("/"),
+										},
+									}},
+									Filters: []gatewayv1.HTTPRouteFilter{{
+										Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+										RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+											Scheme:     ptrTo("https"),
+											StatusCode: ptrTo(301),
+										},
+									}},
+								},
+							},
+						},
+					},
 					{Namespace: "default", Name: "example-ingress-foo-example-com"}: {
 						ObjectMeta: metav1.ObjectMeta{Name: "example-ingress-foo-example-com", Namespace: "default"},
 						Spec: gatewayv1.HTTPRouteSpec{
 							CommonRouteSpec: gatewayv1.CommonRouteSpec{
 								ParentRefs: []gatewayv1.ParentReference{{
-									Name: "nginx",
+									Name:        "nginx",
+									SectionName: ptrTo(gatewayv1.SectionName("foo-example-com-https")),
 								}},
 							},
 							Hostnames: []gatewayv1.Hostname{"foo.example.com"},
@@ -408,6 +478,50 @@ func Test_ToGateway(t *testing.T) {
 							},
 						},
 					},
+					{Namespace: "default", Name: "example-ingress-foo-example-com-http"}: {
+						ObjectMeta: metav1.ObjectMeta{Name: "example-ingress-foo-example-com-http", Namespace: "default"},
+						Spec: gatewayv1.HTTPRouteSpec{
+							CommonRouteSpec: gatewayv1.CommonRouteSpec{
+								ParentRefs: []gatewayv1.ParentReference{{
+									Name:        "nginx",
+									SectionName: ptrTo(gatewayv1.SectionName("foo-example-com-http")),
+								}},
+							},
+							Hostnames: []gatewayv1.Hostname{"foo.example.com"},
+							Rules: []gatewayv1.HTTPRouteRule{
+								{
+									Matches: []gatewayv1.HTTPRouteMatch{{
+										Path: &gatewayv1.HTTPPathMatch{
+											Type:  &gPathPrefix,
+											Value: ptrTo("/"),
+										},
+									}},
+									Filters: []gatewayv1.HTTPRouteFilter{{
+										Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+										RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+											Scheme:     ptrTo("https"),
+											StatusCode: ptrTo(301),
+										},
+									}},
+								},
+								{
+									Matches: []gatewayv1.HTTPRouteMatch{{
+										Path: &gatewayv1.HTTPPathMatch{
+											Type:  &gPathPrefix,
+											Value: ptrTo("/orders"),
+										},
+									}},
+									Filters: []gatewayv1.HTTPRouteFilter{{
+										Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+										RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+											Scheme:     ptrTo("https"),
+											StatusCode: ptrTo(301),
+										},
+									}},
+								},
+							},
+						},
+					},
 				},
 			},
 			expectedErrors: field.ErrorList{},
@@ -633,7 +747,7 @@ func Test_ToGateway(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 
-			provider := NewProvider(&i2gw.ProviderConf{})
+			provider := NewProvider(&i2gw.ProviderConf{Notifications: notifications.NewNotificationAggregator()})
 
 			nginxProvider := provider.(*Provider)
 			nginxProvider.storage.Ingresses = tc.ingresses
@@ -0,0 +1,314 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressnginx
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// maxHeaderModifierSet is the maximum number of headers a header modifier
+// filter can set.
+const maxHeaderModifierSet = 16
+
+// ingressHeaders are the headers an Ingress sets on the requests to its
+// backends and on the responses to the clients.
+type ingressHeaders struct {
+	request  []gatewayv1.HTTPHeader
+	response []gatewayv1.HTTPHeader
+	// hostname is the Host header of the requests to the backends, which is
+	// rewritten with a URLRewrite filter rather than a header modifier.
+	hostname gatewayv1.PreciseHostname
+}
+
+func (h ingressHeaders) empty() bool {
+	return len(h.request) == 0 && len(h.response) == 0 && h.hostname == ""
+}
+
+// headersFeature parses the custom-headers, proxy-set-headers, upstream-vhost
+// and x-forwarded-prefix annotations of the ingresses and patches the rules of
+// the HTTPRoutes with header modifier filters:
+//   - the headers of the custom-headers ConfigMap are set on the responses
+//     with a ResponseHeaderModifier filter;
+//   - the headers of the proxy-set-headers ConfigMap and the X-Forwarded-Prefix
+//     header of x-forwarded-prefix are set on the requests with a
+//     RequestHeaderModifier filter;
+//   - the Host header of upstream-vhost is rewritten with the hostname of a
+//     URLRewrite filter, merged with the one of rewriteFeature if any.
+//
+// The ConfigMaps are referenced as <namespace>/<name>, or <name> in the
+// namespace of the Ingress. Values using nginx variables cannot be expressed
+// in Gateway API, they are kept as is with a warning. A header modifier filter
+// sets at most 16 headers, the ones beyond are dropped with a warning.
+func (c *converter) headersFeature(ingresses []networkingv1.Ingress, gatewayResources *i2gw.GatewayResources, notificationAggr *notifications.NotificationAggregator) field.ErrorList {
+	var errs field.ErrorList
+	headersByIngress := map[types.NamespacedName]ingressHeaders{}
+	for i := range ingresses {
+		headers := c.parseHeadersAnnotations(&ingresses[i], notificationAggr)
+		if !headers.empty() {
+			headersByIngress[types.NamespacedName{Namespace: ingresses[i].Namespace, Name: ingresses[i].Name}] = headers
+		}
+	}
+	if len(headersByIngress) == 0 {
+		return nil
+	}
+
+	var dropped []droppedHeaders
+	ruleGroups := common.GetRuleGroups(ingresses)
+	for _, rg := range ruleGroups {
+		var ruleHeaders []ingressHeaders
+		rgIngresses := ruleIngresses(rg)
+		for _, ingress := range rgIngresses {
+			ruleHeaders = append(ruleHeaders, headersByIngress[types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}])
+		}
+		if !slices.ContainsFunc(ruleHeaders, func(headers ingressHeaders) bool { return !headers.empty() }) {
+			continue
+		}
+
+		key := types.NamespacedName{Namespace: rg.Namespace, Name: common.RouteName(rg.Name, rg.Host)}
+		httpRoute, ok := gatewayResources.HTTPRoutes[key]
+		if !ok {
+			errs = append(errs, field.NotFound(field.NewPath("HTTPRoute"), key))
+			continue
+		}
+
+		var (
+			requestHeaders, responseHeaders []gatewayv1.HTTPHeaderName
+			hostnames                       []gatewayv1.PreciseHostname
+		)
+		for i, headers := range ruleHeaders {
+			if i >= len(httpRoute.Spec.Rules) || isRedirectRule(httpRoute.Spec.Rules[i]) {
+				continue
+			}
+			rule := &httpRoute.Spec.Rules[i]
+			if len(headers.request) > 0 {
+				set, droppedRequest := setHeaders(rule, gatewayv1.HTTPRouteFilterRequestHeaderModifier, headers.request)
+				requestHeaders = appendHeaderNames(requestHeaders, set)
+				dropped = appendDroppedHeaders(dropped, rgIngresses[i], gatewayv1.HTTPRouteFilterRequestHeaderModifier, droppedRequest)
+			}
+			if len(headers.response) > 0 {
+				set, droppedResponse := setHeaders(rule, gatewayv1.HTTPRouteFilterResponseHeaderModifier, headers.response)
+				responseHeaders = appendHeaderNames(responseHeaders, set)
+				dropped = appendDroppedHeaders(dropped, rgIngresses[i], gatewayv1.HTTPRouteFilterResponseHeaderModifier, droppedResponse)
+			}
+			if headers.hostname != "" {
+				setRewriteHostname(rule, headers.hostname)
+				if !slices.Contains(hostnames, headers.hostname) {
+					hostnames = append(hostnames, headers.hostname)
+				}
+			}
+		}
+		for _, name := range requestHeaders {
+			notify(notificationAggr, notifications.InfoNotification, fmt.Sprintf("parsed header annotations of ingress and set the %q request header with a %v filter", name, gatewayv1.HTTPRouteFilterRequestHeaderModifier), &httpRoute)
+		}
+		for _, hostname := range hostnames {
+			notify(notificationAggr, notifications.InfoNotification, fmt.Sprintf("parsed \"%v\" annotation of ingress and rewrote the Host header to %q with a %v filter", nginxAnnotation(upstreamVhostKey), hostname, gatewayv1.HTTPRouteFilterURLRewrite), &httpRoute)
+		}
+		for _, name := range responseHeaders {
+			notify(notificationAggr, notifications.InfoNotification, fmt.Sprintf("parsed \"%v\" annotation of ingress and set the %q response header with a %v filter", nginxAnnotation(customHeadersKey), name, gatewayv1.HTTPRouteFilterResponseHeaderModifier), &httpRoute)
+		}
+		gatewayResources.HTTPRoutes[key] = httpRoute
+	}
+	for i := range dropped {
+		notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("dropped the %v headers set by the header annotations of ingress: a %v filter cannot set more than %d headers", quotedHeaderNames(dropped[i].names), dropped[i].filterType, maxHeaderModifierSet), &dropped[i].ingress)
+	}
+	return errs
+}
+
+// droppedHeaders are the headers of an ingress that could not be set with the
+// header modifier filter of the given type.
+type droppedHeaders struct {
+	ingress    networkingv1.Ingress
+	filterType gatewayv1.HTTPRouteFilterType
+	names      []gatewayv1.HTTPHeaderName
+}
+
+func appendDroppedHeaders(dropped []droppedHeaders, ingress networkingv1.Ingress, filterType gatewayv1.HTTPRouteFilterType, headers []gatewayv1.HTTPHeader) []droppedHeaders {
+	if len(headers) == 0 {
+		return dropped
+	}
+	i := slices.IndexFunc(dropped, func(d droppedHeaders) bool {
+		return d.ingress.Namespace == ingress.Namespace && d.ingress.Name == ingress.Name && d.filterType == filterType
+	})
+	if i < 0 {
+		dropped = append(dropped, droppedHeaders{ingress: ingress, filterType: filterType})
+		i = len(dropped) - 1
+	}
+	dropped[i].names = appendHeaderNames(dropped[i].names, headers)
+	return dropped
+}
+
+func quotedHeaderNames(names []gatewayv1.HTTPHeaderName) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, fmt.Sprintf("%q", name))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// parseHeadersAnnotations returns the headers set by the annotations of the
+// ingress, and notifies the ones that cannot be converted.
+func (c *converter) parseHeadersAnnotations(ingress *networkingv1.Ingress, notificationAggr *notifications.NotificationAggregator) ingressHeaders {
+	var headers ingressHeaders
+	headers.request = append(headers.request, c.configMapHeaders(ingress, proxySetHeadersKey, notificationAggr)...)
+	if vhost := ingress.Annotations[nginxAnnotation(upstreamVhostKey)]; vhost != "" {
+		if errs := validation.IsDNS1123Subdomain(vhost); len(errs) > 0 {
+			notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("ignored \"%v\" annotation %q: only a hostname can be expressed in Gateway API, without port nor nginx variables", nginxAnnotation(upstreamVhostKey), vhost), ingress)
+		} else {
+			headers.hostname = gatewayv1.PreciseHostname(vhost)
+		}
+	}
+	if prefix := ingress.Annotations[nginxAnnotation(xForwardedPrefixKey)]; prefix != "" {
+		headers.request = append(headers.request, gatewayv1.HTTPHeader{Name: "X-Forwarded-Prefix", Value: prefix})
+	}
+	headers.response = c.configMapHeaders(ingress, customHeadersKey, notificationAggr)
+
+	for _, header := range append(slices.Clone(headers.request), headers.response...) {
+		if strings.Contains(header.Value, "$") {
+			notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("the value %q of the %q header uses nginx variables that cannot be expressed in Gateway API, it is kept as is", header.Value, header.Name), ingress)
+		}
+	}
+	return headers
+}
+
+// configMapHeaders returns the headers of the ConfigMap referenced by the
+// annotation of the ingress, sorted by name.
+func (c *converter) configMapHeaders(ingress *networkingv1.Ingress, annotationKey string, notificationAggr *notifications.NotificationAggregator) []gatewayv1.HTTPHeader {
+	reference := ingress.Annotations[nginxAnnotation(annotationKey)]
+	if reference == "" {
+		return nil
+	}
+	key, ok := configMapKey(ingress.Namespace, reference)
+	if !ok {
+		notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("ignored \"%v\" annotation %q: expected a ConfigMap reference <namespace>/<name>", nginxAnnotation(annotationKey), reference), ingress)
+		return nil
+	}
+	configMap, ok := c.configMaps[key]
+	if !ok {
+		notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("ignored \"%v\" annotation: ConfigMap %s not found", nginxAnnotation(annotationKey), key), ingress)
+		return nil
+	}
+
+	var headers []gatewayv1.HTTPHeader
+	for name, value := range configMap.Data {
+		headers = append(headers, gatewayv1.HTTPHeader{Name: gatewayv1.HTTPHeaderName(name), Value: value})
+	}
+	slices.SortFunc(headers, func(a, b gatewayv1.HTTPHeader) int {
+		return strings.Compare(string(a.Name), string(b.Name))
+	})
+	return headers
+}
+
+// configMapReferences returns the keys of the ConfigMaps referenced by the
+// annotations of the ingresses.
+func configMapReferences(ingresses []networkingv1.Ingress) []types.NamespacedName {
+	var keys []types.NamespacedName
+	for _, ingress := range ingresses {
+		for _, annotationKey := range []string{customHeadersKey, proxySetHeadersKey} {
+			reference := ingress.Annotations[nginxAnnotation(annotationKey)]
+			if key, ok := configMapKey(ingress.Namespace, reference); ok && !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// configMapKey parses a <namespace>/<name> or <name> ConfigMap reference.
+func configMapKey(namespace, reference string) (types.NamespacedName, bool) {
+	parts := strings.Split(reference, "/")
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		return types.NamespacedName{Namespace: namespace, Name: parts[0]}, true
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return types.NamespacedName{Namespace: parts[0], Name: parts[1]}, true
+	default:
+		return types.NamespacedName{}, false
+	}
+}
+
+// setHeaders sets the headers with the header modifier filter of the given
+// type of the rule, which is added if missing. It returns the headers set, and
+// the ones dropped as the filter already sets maxHeaderModifierSet headers.
+func setHeaders(rule *gatewayv1.HTTPRouteRule, filterType gatewayv1.HTTPRouteFilterType, headers []gatewayv1.HTTPHeader) (set, dropped []gatewayv1.HTTPHeader) {
+	i := slices.IndexFunc(rule.Filters, func(filter gatewayv1.HTTPRouteFilter) bool { return filter.Type == filterType })
+	if i < 0 {
+		filter := gatewayv1.HTTPRouteFilter{Type: filterType}
+		if filterType == gatewayv1.HTTPRouteFilterRequestHeaderModifier {
+			filter.RequestHeaderModifier = &gatewayv1.HTTPHeaderFilter{}
+		} else {
+			filter.ResponseHeaderModifier = &gatewayv1.HTTPHeaderFilter{}
+		}
+		rule.Filters = append(rule.Filters, filter)
+		i = len(rule.Filters) - 1
+	}
+	headerFilter := rule.Filters[i].RequestHeaderModifier
+	if filterType == gatewayv1.HTTPRouteFilterResponseHeaderModifier {
+		headerFilter = rule.Filters[i].ResponseHeaderModifier
+	}
+
+	for _, header := range headers {
+		j := slices.IndexFunc(headerFilter.Set, func(set gatewayv1.HTTPHeader) bool { return strings.EqualFold(string(set.Name), string(header.Name)) })
+		switch {
+		case j >= 0:
+			headerFilter.Set[j] = header
+		case len(headerFilter.Set) < maxHeaderModifierSet:
+			headerFilter.Set = append(headerFilter.Set, header)
+		default:
+			dropped = append(dropped, header)
+			continue
+		}
+		set = append(set, header)
+	}
+	return set, dropped
+}
+
+// setRewriteHostname sets the hostname of the URLRewrite filter of the rule,
+// which is added if missing.
+func setRewriteHostname(rule *gatewayv1.HTTPRouteRule, hostname gatewayv1.PreciseHostname) {
+	i := slices.IndexFunc(rule.Filters, func(filter gatewayv1.HTTPRouteFilter) bool { return filter.Type == gatewayv1.HTTPRouteFilterURLRewrite })
+	if i < 0 {
+		rule.Filters = append(rule.Filters, gatewayv1.HTTPRouteFilter{Type: gatewayv1.HTTPRouteFilterURLRewrite, URLRewrite: &gatewayv1.HTTPURLRewriteFilter{}})
+		i = len(rule.Filters) - 1
+	}
+	rule.Filters[i].URLRewrite.Hostname = &hostname
+}
+
+func appendHeaderNames(names []gatewayv1.HTTPHeaderName, headers []gatewayv1.HTTPHeader) []gatewayv1.HTTPHeaderName {
+	for _, header := range headers {
+		if !slices.Contains(names, header.Name) {
+			names = append(names, header.Name)
+		}
+	}
+	return names
+}
+
+func isRedirectRule(rule gatewayv1.HTTPRouteRule) bool {
+	return slices.ContainsFunc(rule.Filters, func(filter gatewayv1.HTTPRouteFilter) bool {
+		return filter.Type == gatewayv1.HTTPRouteFilterRequestRedirect
+	})
+}
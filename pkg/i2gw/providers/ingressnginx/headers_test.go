@@ -0,0 +1,320 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressnginx
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	apiv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func Test_headersFeature(t *testing.T) {
+	configMaps := map[types.NamespacedName]*apiv1.ConfigMap{
+		{Namespace: "ingress-nginx", Name: "security-headers"}: {
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ingress-nginx", Name: "security-headers"},
+			Data: map[string]string{
+				"X-Frame-Options":        "DENY",
+				"X-Content-Type-Options": "nosniff",
+			},
+		},
+		{Namespace: "default", Name: "proxy-headers"}: {
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "proxy-headers"},
+			Data: map[string]string{
+				"X-Team": "web",
+			},
+		},
+	}
+	manyHeaders := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "many-headers"},
+		Data:       map[string]string{},
+	}
+	var manyHeadersSet []gatewayv1.HTTPHeader
+	for i := 0; i < maxHeaderModifierSet+2; i++ {
+		name := fmt.Sprintf("X-Header-%02d", i)
+		manyHeaders.Data[name] = "on"
+		if i < maxHeaderModifierSet {
+			manyHeadersSet = append(manyHeadersSet, gatewayv1.HTTPHeader{Name: gatewayv1.HTTPHeaderName(name), Value: "on"})
+		}
+	}
+	configMaps[types.NamespacedName{Namespace: "default", Name: "many-headers"}] = manyHeaders
+
+	ingress := func(annotations map[string]string) networkingv1.Ingress {
+		return networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", Annotations: annotations},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: ptr.To("nginx"),
+				Rules: []networkingv1.IngressRule{{
+					Host: "example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{{
+							Path:     "/",
+							PathType: ptr.To(networkingv1.PathTypePrefix),
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{Name: "web", Port: networkingv1.ServiceBackendPort{Number: 80}},
+							},
+						}}},
+					},
+				}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name             string
+		ingress          networkingv1.Ingress
+		expectedFilters  []gatewayv1.HTTPRouteFilter
+		expectedInfos    int
+		expectedWarnings int
+	}{
+		{
+			name:    "no annotations",
+			ingress: ingress(nil),
+		},
+		{
+			name: "request and response headers",
+			ingress: ingress(map[string]string{
+				"nginx.ingress.kubernetes.io/custom-headers":     "ingress-nginx/security-headers",
+				"nginx.ingress.kubernetes.io/proxy-set-headers":  "proxy-headers",
+				"nginx.ingress.kubernetes.io/upstream-vhost":     "web.internal",
+				"nginx.ingress.kubernetes.io/x-forwarded-prefix": "/web",
+			}),
+			expectedFilters: []gatewayv1.HTTPRouteFilter{
+				{
+					Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+					RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{Set: []gatewayv1.HTTPHeader{
+						{Name: "X-Team", Value: "web"},
+						{Name: "X-Forwarded-Prefix", Value: "/web"},
+					}},
+				},
+				{
+					Type: gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+					ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{Set: []gatewayv1.HTTPHeader{
+						{Name: "X-Content-Type-Options", Value: "nosniff"},
+						{Name: "X-Frame-Options", Value: "DENY"},
+					}},
+				},
+				{
+					Type:       gatewayv1.HTTPRouteFilterURLRewrite,
+					URLRewrite: &gatewayv1.HTTPURLRewriteFilter{Hostname: ptr.To(gatewayv1.PreciseHostname("web.internal"))},
+				},
+			},
+			expectedInfos: 5,
+		},
+		{
+			name: "upstream vhost merged with the rewrite target",
+			ingress: ingress(map[string]string{
+				"nginx.ingress.kubernetes.io/rewrite-target": "/app",
+				"nginx.ingress.kubernetes.io/upstream-vhost": "web.internal",
+			}),
+			expectedFilters: []gatewayv1.HTTPRouteFilter{{
+				Type: gatewayv1.HTTPRouteFilterURLRewrite,
+				URLRewrite: &gatewayv1.HTTPURLRewriteFilter{
+					Hostname: ptr.To(gatewayv1.PreciseHostname("web.internal")),
+					Path:     &gatewayv1.HTTPPathModifier{Type: gatewayv1.FullPathHTTPPathModifier, ReplaceFullPath: ptr.To("/app")},
+				},
+			}},
+			expectedInfos: 1,
+		},
+		{
+			name:    "more headers than a filter can set",
+			ingress: ingress(map[string]string{"nginx.ingress.kubernetes.io/custom-headers": "many-headers"}),
+			expectedFilters: []gatewayv1.HTTPRouteFilter{{
+				Type:                   gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+				ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{Set: manyHeadersSet},
+			}},
+			expectedInfos:    maxHeaderModifierSet,
+			expectedWarnings: 1,
+		},
+		{
+			name:             "missing ConfigMap",
+			ingress:          ingress(map[string]string{"nginx.ingress.kubernetes.io/custom-headers": "ingress-nginx/missing"}),
+			expectedWarnings: 1,
+		},
+		{
+			name:    "nginx variable",
+			ingress: ingress(map[string]string{"nginx.ingress.kubernetes.io/x-forwarded-prefix": "$prefix"}),
+			expectedFilters: []gatewayv1.HTTPRouteFilter{{
+				Type:                  gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+				RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{Set: []gatewayv1.HTTPHeader{{Name: "X-Forwarded-Prefix", Value: "$prefix"}}},
+			}},
+			expectedInfos:    1,
+			expectedWarnings: 1,
+		},
+		{
+			name:             "upstream vhost that is not a hostname",
+			ingress:          ingress(map[string]string{"nginx.ingress.kubernetes.io/upstream-vhost": "$host"}),
+			expectedWarnings: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ingresses := []networkingv1.Ingress{tc.ingress}
+			gatewayResources, errs := common.ToGateway(ingresses, i2gw.ProviderImplementationSpecificOptions{})
+			if len(errs) > 0 {
+				t.Fatalf("Expected no errors converting the ingresses, got %v", errs)
+			}
+
+			// The URLRewrite filter of rewriteFeature is merged.
+			if errs = rewriteFeature(ingresses, &gatewayResources, notifications.NewNotificationAggregator()); len(errs) > 0 {
+				t.Fatalf("Expected no errors rewriting the paths, got %v", errs)
+			}
+
+			c := &converter{configMaps: configMaps}
+			notificationAggr := notifications.NewNotificationAggregator()
+			if errs = c.headersFeature(ingresses, &gatewayResources, notificationAggr); len(errs) > 0 {
+				t.Fatalf("Expected no errors, got %v", errs)
+			}
+
+			httpRoute := gatewayResources.HTTPRoutes[types.NamespacedName{Namespace: "default", Name: common.RouteName("web", "example.com")}]
+			if diff := cmp.Diff(tc.expectedFilters, httpRoute.Spec.Rules[0].Filters); diff != "" {
+				t.Errorf("Unexpected filters, diff (-want +got):\n%s", diff)
+			}
+			warnings := notificationAggr.CountAtLeast(notifications.WarningNotification)
+			if infos := notificationAggr.CountAtLeast(notifications.InfoNotification) - warnings; infos != tc.expectedInfos {
+				t.Errorf("Expected %d info notifications, got %d", tc.expectedInfos, infos)
+			}
+			if warnings != tc.expectedWarnings {
+				t.Errorf("Expected %d warnings, got %d", tc.expectedWarnings, warnings)
+			}
+		})
+	}
+}
+
+func Test_headersFeatureUnreadableConfigMap(t *testing.T) {
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "web",
+			Name:      "web",
+			Annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/custom-headers":    "ingress-nginx/custom-headers",
+				"nginx.ingress.kubernetes.io/proxy-set-headers": "proxy-headers",
+			},
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: ptr.To("nginx"),
+			Rules: []networkingv1.IngressRule{{
+				Host: "example.com",
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{{
+						Path:     "/",
+						PathType: ptr.To(networkingv1.PathTypePrefix),
+						Backend: networkingv1.IngressBackend{
+							Service: &networkingv1.IngressServiceBackend{Name: "web", Port: networkingv1.ServiceBackendPort{Number: 80}},
+						},
+					}}},
+				},
+			}},
+		},
+	}
+	customHeaders := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ingress-nginx", Name: "custom-headers"},
+		Data:       map[string]string{"X-Frame-Options": "DENY"},
+	}
+	proxyHeaders := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "web", Name: "proxy-headers"},
+		Data:       map[string]string{"X-Team": "web"},
+	}
+
+	// The namespaced client needs the scope of the objects it gets.
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{apiv1.SchemeGroupVersion})
+	restMapper.Add(apiv1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	clientBuilder := func() *fake.ClientBuilder {
+		return fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithRESTMapper(restMapper).WithObjects(ingress, customHeaders, proxyHeaders)
+	}
+
+	testCases := []struct {
+		name      string
+		namespace string
+		client    client.Client
+	}{
+		{
+			name:      "ConfigMap outside of the namespace",
+			namespace: "web",
+			client:    client.NewNamespacedClient(clientBuilder().Build(), "web"),
+		},
+		{
+			name: "ConfigMap get forbidden",
+			client: clientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, cl client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if key.Name == "custom-headers" {
+						return apierrors.NewForbidden(schema.GroupResource{Resource: "configmaps"}, key.Name, fmt.Errorf("no RBAC"))
+					}
+					return cl.Get(ctx, key, obj, opts...)
+				},
+			}).Build(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			notificationAggr := notifications.NewNotificationAggregator()
+			provider := NewProvider(&i2gw.ProviderConf{
+				Client:        tc.client,
+				Namespace:     tc.namespace,
+				Notifications: notificationAggr,
+			})
+			if err := provider.ReadResourcesFromCluster(context.Background()); err != nil {
+				t.Fatalf("Expected no error reading the resources, got %v", err)
+			}
+			gatewayResources, errs := provider.ToGatewayAPI()
+			if len(errs) > 0 {
+				t.Fatalf("Expected no errors, got %v", errs)
+			}
+
+			httpRoute := gatewayResources.HTTPRoutes[types.NamespacedName{Namespace: "web", Name: "web-example-com"}]
+			expectedFilters := []gatewayv1.HTTPRouteFilter{{
+				Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+				RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+					Set: []gatewayv1.HTTPHeader{{Name: "X-Team", Value: "web"}},
+				},
+			}}
+			if diff := cmp.Diff(expectedFilters, httpRoute.Spec.Rules[0].Filters); diff != "" {
+				t.Errorf("Unexpected filters, diff (-want +got):\n%s", diff)
+			}
+
+			notFound := slices.ContainsFunc(notificationAggr.Notifications[Name], func(n notifications.Notification) bool {
+				return n.Type == notifications.WarningNotification && strings.Contains(n.Message, "ConfigMap ingress-nginx/custom-headers not found")
+			})
+			if !notFound {
+				t.Errorf("Expected a warning for the ConfigMap ingress-nginx/custom-headers, got %v", notificationAggr.Notifications[Name])
+			}
+		})
+	}
+}
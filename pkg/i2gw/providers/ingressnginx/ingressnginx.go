@@ -19,6 +19,7 @@ package ingressnginx
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -28,8 +29,18 @@ import (
 const Name = "ingress-nginx"
 const NginxIngressClass = "nginx"
 
+// PolicyEmitterFlag is the provider-specific flag selecting the implementation
+// the policies carrying the annotations without core Gateway API equivalent
+// are emitted for.
+const PolicyEmitterFlag = "policy-emitter"
+
 func init() {
 	i2gw.ProviderConstructorByName[Name] = NewProvider
+
+	i2gw.RegisterProviderSpecificFlag(Name, i2gw.ProviderSpecificFlag{
+		Name:        PolicyEmitterFlag,
+		Description: fmt.Sprintf("The implementation to emit policies for, carrying the annotations without core Gateway API equivalent, one of: %s. No policies are emitted by default.", strings.Join(policyEmitterNames(), ", ")),
+	})
 }
 
 // Provider implements the i2gw.Provider interface.
@@ -44,7 +55,7 @@ func NewProvider(conf *i2gw.ProviderConf) i2gw.Provider {
 	return &Provider{
 		storage:        newResourcesStorage(),
 		resourceReader: newResourceReader(conf),
-		converter:      newConverter(),
+		converter:      newConverter(conf),
 	}
 }
 
@@ -64,10 +75,10 @@ func (p *Provider) ReadResourcesFromCluster(ctx context.Context) error {
 	return nil
 }
 
-func (p *Provider) ReadResourcesFromFile(_ context.Context, filename string) error {
-	storage, err := p.resourceReader.readResourcesFromFile(filename)
+func (p *Provider) ReadResourcesFromInputs(_ context.Context, inputs []i2gw.Input) error {
+	storage, err := p.resourceReader.readResourcesFromInputs(inputs)
 	if err != nil {
-		return fmt.Errorf("failed to read resources from file: %w", err)
+		return fmt.Errorf("failed to read resources from the inputs: %w", err)
 	}
 
 	p.storage = storage
@@ -18,10 +18,22 @@ package ingressnginx
 
 import (
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func notify(mType notifications.MessageType, message string, callingObject ...client.Object) {
+func notify(notificationAggr *notifications.NotificationAggregator, mType notifications.MessageType, message string, callingObject ...client.Object) {
 	newNotification := notifications.NewNotification(mType, message, callingObject...)
-	notifications.NotificationAggr.DispatchNotification(newNotification, string(Name))
+	notificationAggr.DispatchNotification(newNotification, string(Name))
+}
+
+func notifyField(notificationAggr *notifications.NotificationAggregator, mType notifications.MessageType, fieldPath *field.Path, message string, callingObject ...client.Object) {
+	newNotification := notifications.NewFieldNotification(mType, fieldPath, message, callingObject...)
+	notificationAggr.DispatchNotification(newNotification, string(Name))
+}
+
+func dispatchNotification(notificationAggr *notifications.NotificationAggregator, n []notifications.Notification) {
+	for _, v := range n {
+		notificationAggr.DispatchNotification(v, string(Name))
+	}
 }
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressnginx
+
+import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// proxySettings are the settings of the proxy annotations of an Ingress that
+// core Gateway API cannot express. The zero value of a field means the setting
+// is left to the default of the implementation.
+type proxySettings struct {
+	// connectTimeout is the timeout to establish a connection with a backend,
+	// from proxy-connect-timeout.
+	connectTimeout gatewayv1.Duration
+	// bodySizeLimit is the maximum size of the request bodies, as a resource
+	// quantity, from proxy-body-size.
+	bodySizeLimit string
+	// responseBuffering is whether the responses of the backends are
+	// buffered, from proxy-buffering.
+	responseBuffering bool
+}
+
+// annotationKeys returns the keys of the annotations of the settings.
+func (s proxySettings) annotationKeys() []string {
+	var keys []string
+	if s.connectTimeout != "" {
+		keys = append(keys, proxyConnectTimeoutKey)
+	}
+	if s.bodySizeLimit != "" {
+		keys = append(keys, proxyBodySizeKey)
+	}
+	if s.responseBuffering {
+		keys = append(keys, proxyBufferingKey)
+	}
+	return keys
+}
+
+// policyEmitter emits the implementation-specific policies carrying the
+// proxySettings of HTTPRoutes.
+type policyEmitter interface {
+	// emit returns the policies applying the settings to the HTTPRoutes of
+	// the given namespace, the first of them naming the policies, and the
+	// keys of the annotations whose settings the policies cannot carry.
+	emit(namespace string, routeNames []string, settings proxySettings) ([]unstructured.Unstructured, []string)
+}
+
+// policyEmitters are the policy emitters, by the name the PolicyEmitterFlag
+// selects them with.
+var policyEmitters = map[string]policyEmitter{
+	"envoy-gateway": envoyGatewayEmitter{},
+}
+
+func policyEmitterNames() []string {
+	names := make([]string, 0, len(policyEmitters))
+	for name := range policyEmitters {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// envoyGatewayEmitter emits an Envoy Gateway BackendTrafficPolicy targeting
+// the HTTPRoutes. Envoy does not buffer the responses of the backends, which
+// cannot be enabled.
+type envoyGatewayEmitter struct{}
+
+func (envoyGatewayEmitter) emit(namespace string, routeNames []string, settings proxySettings) ([]unstructured.Unstructured, []string) {
+	var unsupported []string
+	if settings.responseBuffering {
+		unsupported = append(unsupported, proxyBufferingKey)
+	}
+	if settings.connectTimeout == "" && settings.bodySizeLimit == "" {
+		return nil, unsupported
+	}
+
+	var targetRefs []interface{}
+	for _, name := range routeNames {
+		targetRefs = append(targetRefs, map[string]interface{}{
+			"group": gatewayv1.GroupName,
+			"kind":  "HTTPRoute",
+			"name":  name,
+		})
+	}
+	spec := map[string]interface{}{"targetRefs": targetRefs}
+	if settings.connectTimeout != "" {
+		spec["timeout"] = map[string]interface{}{
+			"tcp": map[string]interface{}{"connectTimeout": string(settings.connectTimeout)},
+		}
+	}
+	if settings.bodySizeLimit != "" {
+		// Like ingress-nginx, Envoy then buffers the request bodies and
+		// rejects the ones exceeding the limit with a 413 status code.
+		spec["requestBuffer"] = map[string]interface{}{"limit": settings.bodySizeLimit}
+	}
+
+	policy := unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	policy.SetGroupVersionKind(schema.GroupVersionKind{Group: "gateway.envoyproxy.io", Version: "v1alpha1", Kind: "BackendTrafficPolicy"})
+	policy.SetNamespace(namespace)
+	policy.SetName(routeNames[0])
+	return []unstructured.Unstructured{policy}, unsupported
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressnginx
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// nginxSizeRegex matches the nginx sizes, e.g. 8m, in bytes, kilobytes,
+// megabytes or gigabytes.
+var nginxSizeRegex = regexp.MustCompile(`^([0-9]+)([kKmMgG]?)$`)
+
+// proxyAnnotations are the proxy annotations of an Ingress.
+type proxyAnnotations struct {
+	// timeout is the longest of proxy-read-timeout and proxy-send-timeout.
+	timeout  gatewayv1.Duration
+	settings proxySettings
+}
+
+// proxyFeature parses the proxy-read-timeout, proxy-send-timeout,
+// proxy-connect-timeout, proxy-body-size and proxy-buffering annotations of the
+// ingresses:
+//   - ingress-nginx times out when the backends do not respond, or accept the
+//     request, for proxy-read-timeout, or proxy-send-timeout, seconds. The
+//     request and backend request timeouts of the rules are set to the longest
+//     of them, as Gateway API timeouts bound the whole request.
+//   - the other settings have no core Gateway API equivalent, and are carried
+//     by the policies of the policy emitter selected with PolicyEmitterFlag,
+//     targeting the HTTPRoutes.
+//
+// The settings no policy carries, and the invalid values, which are ignored,
+// are notified with a warning. The HTTPRoutes
+// split by sslRedirectFeature are patched as well, so the feature must run
+// after it.
+func (c *converter) proxyFeature(ingresses []networkingv1.Ingress, gatewayResources *i2gw.GatewayResources, notificationAggr *notifications.NotificationAggregator) field.ErrorList {
+	var errs field.ErrorList
+	annotationsByIngress := map[types.NamespacedName]proxyAnnotations{}
+	for i := range ingresses {
+		ingress := &ingresses[i]
+		annotations := parseProxyAnnotations(ingress, notificationAggr)
+		if c.policyEmitter == nil {
+			for _, key := range annotations.settings.annotationKeys() {
+				notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("ignored \"%v\" annotation: it has no core Gateway API equivalent, select the implementation to emit policies for with the --%s-%s flag", nginxAnnotation(key), Name, PolicyEmitterFlag), ingress)
+			}
+			annotations.settings = proxySettings{}
+		}
+		if annotations != (proxyAnnotations{}) {
+			annotationsByIngress[types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}] = annotations
+		}
+	}
+	if len(annotationsByIngress) == 0 {
+		return errs
+	}
+
+	ruleGroups := common.GetRuleGroups(ingresses)
+	for _, rg := range ruleGroups {
+		var (
+			ruleAnnotations []proxyAnnotations
+			annotated       []networkingv1.Ingress
+		)
+		for _, ingress := range ruleIngresses(rg) {
+			annotations := annotationsByIngress[types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}]
+			ruleAnnotations = append(ruleAnnotations, annotations)
+			if annotations != (proxyAnnotations{}) && !containsIngress(annotated, ingress) {
+				annotated = append(annotated, ingress)
+			}
+		}
+		if len(annotated) == 0 {
+			continue
+		}
+
+		key := types.NamespacedName{Namespace: rg.Namespace, Name: common.RouteName(rg.Name, rg.Host)}
+		httpRoute, ok := gatewayResources.HTTPRoutes[key]
+		if !ok {
+			errs = append(errs, field.NotFound(field.NewPath("HTTPRoute"), key))
+			continue
+		}
+		// The HTTPRoute split by sslRedirectFeature also forwards the paths
+		// that are not redirected.
+		routeNames := []string{key.Name}
+		if redirectRoute, ok := gatewayResources.HTTPRoutes[types.NamespacedName{Namespace: key.Namespace, Name: key.Name + "-http"}]; ok &&
+			slices.ContainsFunc(redirectRoute.Spec.Rules, func(rule gatewayv1.HTTPRouteRule) bool { return !isRedirectRule(rule) }) {
+			routeNames = append(routeNames, redirectRoute.Name)
+		}
+
+		// The settings of the policies targeting the HTTPRoutes, which must
+		// be the same for every rule forwarding to the backends.
+		var (
+			settings                   proxySettings
+			forwarded, settingsDiffers bool
+		)
+		for i, annotations := range ruleAnnotations {
+			if i >= len(httpRoute.Spec.Rules) || isRedirectRule(httpRoute.Spec.Rules[i]) {
+				continue
+			}
+			if !forwarded {
+				settings, forwarded = annotations.settings, true
+			}
+			settingsDiffers = settingsDiffers || settings != annotations.settings
+		}
+
+		for _, name := range routeNames {
+			routeKey := types.NamespacedName{Namespace: key.Namespace, Name: name}
+			route := gatewayResources.HTTPRoutes[routeKey]
+			patched := false
+			for i, annotations := range ruleAnnotations {
+				if i >= len(route.Spec.Rules) || isRedirectRule(route.Spec.Rules[i]) || annotations.timeout == "" {
+					continue
+				}
+				timeout := annotations.timeout
+				route.Spec.Rules[i].Timeouts = &gatewayv1.HTTPRouteTimeouts{Request: &timeout, BackendRequest: &timeout}
+				patched = true
+			}
+			if patched {
+				gatewayResources.HTTPRoutes[routeKey] = route
+				notify(notificationAggr, notifications.InfoNotification, fmt.Sprintf("parsed \"%v\" and \"%v\" annotations of ingress and patched %v fields: Gateway API timeouts bound the whole request rather than the time between two reads or writes", nginxAnnotation(proxyReadTimeoutKey), nginxAnnotation(proxySendTimeoutKey), field.NewPath("httproute", "spec", "rules").Key("").Child("timeouts")), &route)
+			}
+		}
+
+		if settings == (proxySettings{}) && !settingsDiffers {
+			continue
+		}
+		callingObjects := make([]client.Object, 0, len(annotated))
+		for i := range annotated {
+			callingObjects = append(callingObjects, &annotated[i])
+		}
+		if settingsDiffers {
+			notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("ignored the annotations without core Gateway API equivalent of the ingresses of HTTPRoute %s: they differ across its paths, and a policy applies to the whole HTTPRoute", key), callingObjects...)
+			continue
+		}
+
+		policies, unsupported := c.policyEmitter.emit(key.Namespace, routeNames, settings)
+		for _, annotationKey := range unsupported {
+			notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("ignored \"%v\" annotation: it cannot be carried by the policies of the %s policy emitter", nginxAnnotation(annotationKey), c.policyEmitterName), callingObjects...)
+		}
+		for _, policy := range policies {
+			addPolicy(gatewayResources, policy, gatewayResources.Sources[i2gw.ObjectRefFor(&httpRoute)])
+			notify(notificationAggr, notifications.InfoNotification, fmt.Sprintf("parsed proxy annotations of ingress and emitted %s %s/%s", policy.GetKind(), policy.GetNamespace(), policy.GetName()), callingObjects...)
+		}
+	}
+	return errs
+}
+
+// parseProxyAnnotations returns the proxy annotations of the ingress. The
+// annotations with an invalid value are ignored with a warning.
+func parseProxyAnnotations(ingress *networkingv1.Ingress, notificationAggr *notifications.NotificationAggregator) proxyAnnotations {
+	var annotations proxyAnnotations
+	fieldPath := field.NewPath(ingress.Name).Child("metadata").Child("annotations")
+
+	timeoutSeconds := 0
+	for _, key := range []string{proxyReadTimeoutKey, proxySendTimeoutKey} {
+		timeoutSeconds = max(timeoutSeconds, parseTimeoutSeconds(ingress, key, fieldPath, notificationAggr))
+	}
+	if timeoutSeconds > 0 {
+		annotations.timeout = secondsDuration(timeoutSeconds)
+	}
+
+	if seconds := parseTimeoutSeconds(ingress, proxyConnectTimeoutKey, fieldPath, notificationAggr); seconds > 0 {
+		annotations.settings.connectTimeout = secondsDuration(seconds)
+	}
+
+	if size, ok := ingress.Annotations[nginxAnnotation(proxyBodySizeKey)]; ok {
+		match := nginxSizeRegex.FindStringSubmatch(size)
+		switch {
+		case match == nil:
+			notifyField(notificationAggr, notifications.WarningNotification, fieldPath.Key(nginxAnnotation(proxyBodySizeKey)), fmt.Sprintf("ignored \"%v\" annotation %q: expected an nginx size, e.g. 8m", nginxAnnotation(proxyBodySizeKey), size), ingress)
+		case strings.TrimLeft(match[1], "0") == "":
+			// A size of 0 disables the limit.
+		default:
+			suffixes := map[string]string{"k": "Ki", "m": "Mi", "g": "Gi"}
+			annotations.settings.bodySizeLimit = match[1] + suffixes[strings.ToLower(match[2])]
+		}
+	}
+
+	annotations.settings.responseBuffering = ingress.Annotations[nginxAnnotation(proxyBufferingKey)] == "on"
+	return annotations
+}
+
+// parseTimeoutSeconds returns the timeout of the annotation of the ingress, in
+// seconds, or 0 when not set or invalid, which is notified with a warning.
+func parseTimeoutSeconds(ingress *networkingv1.Ingress, annotationKey string, fieldPath *field.Path, notificationAggr *notifications.NotificationAggregator) int {
+	value, ok := ingress.Annotations[nginxAnnotation(annotationKey)]
+	if !ok {
+		return 0
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds <= 0 {
+		notifyField(notificationAggr, notifications.WarningNotification, fieldPath.Key(nginxAnnotation(annotationKey)), fmt.Sprintf("ignored \"%v\" annotation %q: expected a positive number of seconds", nginxAnnotation(annotationKey), value), ingress)
+		return 0
+	}
+	return seconds
+}
+
+// secondsDuration returns the Gateway API duration of the given seconds, e.g.
+// 1h30m for 5400.
+func secondsDuration(seconds int) gatewayv1.Duration {
+	var duration string
+	if hours := seconds / 3600; hours > 0 {
+		duration += fmt.Sprintf("%dh", hours)
+	}
+	if minutes := seconds % 3600 / 60; minutes > 0 {
+		duration += fmt.Sprintf("%dm", minutes)
+	}
+	if seconds%60 > 0 || duration == "" {
+		duration += fmt.Sprintf("%ds", seconds%60)
+	}
+	return gatewayv1.Duration(duration)
+}
+
+// addPolicy adds the policy to the gateway resources, converted from the given
+// sources.
+func addPolicy(gatewayResources *i2gw.GatewayResources, policy unstructured.Unstructured, sources []i2gw.ObjectRef) {
+	if gatewayResources.Policies == nil {
+		gatewayResources.Policies = map[i2gw.ObjectRef]unstructured.Unstructured{}
+	}
+	ref := i2gw.ObjectRefFor(&policy)
+	gatewayResources.Policies[ref] = policy
+	gatewayResources.AddSources(&policy, sources...)
+}
+
+func containsIngress(ingresses []networkingv1.Ingress, ingress networkingv1.Ingress) bool {
+	for _, i := range ingresses {
+		if i.Namespace == ingress.Namespace && i.Name == ingress.Name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressnginx
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func Test_parseProxyAnnotations(t *testing.T) {
+	testCases := []struct {
+		name                string
+		annotations         map[string]string
+		expectedAnnotations proxyAnnotations
+		expectedWarnings    int
+	}{
+		{
+			name: "no annotations",
+		},
+		{
+			name: "longest of the read and send timeouts",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/proxy-read-timeout":    "5400",
+				"nginx.ingress.kubernetes.io/proxy-send-timeout":    "120",
+				"nginx.ingress.kubernetes.io/proxy-connect-timeout": "10",
+			},
+			expectedAnnotations: proxyAnnotations{
+				timeout:  "1h30m",
+				settings: proxySettings{connectTimeout: "10s"},
+			},
+		},
+		{
+			name: "body size and buffering",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/proxy-body-size": "8m",
+				"nginx.ingress.kubernetes.io/proxy-buffering": "on",
+			},
+			expectedAnnotations: proxyAnnotations{
+				settings: proxySettings{bodySizeLimit: "8Mi", responseBuffering: true},
+			},
+		},
+		{
+			name:        "unlimited body size",
+			annotations: map[string]string{"nginx.ingress.kubernetes.io/proxy-body-size": "0"},
+		},
+		{
+			name: "invalid timeout",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/proxy-read-timeout": "60s",
+				"nginx.ingress.kubernetes.io/proxy-send-timeout": "30",
+			},
+			expectedAnnotations: proxyAnnotations{timeout: "30s"},
+			expectedWarnings:    1,
+		},
+		{
+			name: "invalid body size",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/proxy-body-size": "8MB",
+				"nginx.ingress.kubernetes.io/proxy-buffering": "on",
+			},
+			expectedAnnotations: proxyAnnotations{settings: proxySettings{responseBuffering: true}},
+			expectedWarnings:    1,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", Annotations: tc.annotations}}
+			notificationAggr := notifications.NewNotificationAggregator()
+			annotations := parseProxyAnnotations(ingress, notificationAggr)
+			if diff := cmp.Diff(tc.expectedAnnotations, annotations, cmp.AllowUnexported(proxyAnnotations{}, proxySettings{})); diff != "" {
+				t.Errorf("Unexpected annotations, diff (-want +got):\n%s", diff)
+			}
+			if warnings := notificationAggr.CountAtLeast(notifications.WarningNotification); warnings != tc.expectedWarnings {
+				t.Errorf("Expected %d warnings, got %d", tc.expectedWarnings, warnings)
+			}
+		})
+	}
+}
+
+func Test_proxyFeature(t *testing.T) {
+	ingress := func(name string, annotations map[string]string, path string) networkingv1.Ingress {
+		return networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Annotations: annotations},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: ptr.To("nginx"),
+				Rules: []networkingv1.IngressRule{{
+					Host: "example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{{
+							Path:     path,
+							PathType: ptr.To(networkingv1.PathTypePrefix),
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{Name: name, Port: networkingv1.ServiceBackendPort{Number: 80}},
+							},
+						}}},
+					},
+				}},
+			},
+		}
+	}
+	timeouts := func(timeout gatewayv1.Duration) *gatewayv1.HTTPRouteTimeouts {
+		return &gatewayv1.HTTPRouteTimeouts{Request: &timeout, BackendRequest: &timeout}
+	}
+	backendTrafficPolicy := func(spec map[string]interface{}) unstructured.Unstructured {
+		spec["targetRefs"] = []interface{}{map[string]interface{}{"group": "gateway.networking.k8s.io", "kind": "HTTPRoute", "name": "web-example-com"}}
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "gateway.envoyproxy.io/v1alpha1",
+			"kind":       "BackendTrafficPolicy",
+			"metadata":   map[string]interface{}{"namespace": "default", "name": "web-example-com"},
+			"spec":       spec,
+		}}
+	}
+
+	testCases := []struct {
+		name             string
+		ingresses        []networkingv1.Ingress
+		policyEmitter    string
+		expectedTimeouts []*gatewayv1.HTTPRouteTimeouts
+		expectedPolicies []unstructured.Unstructured
+		expectedWarnings int
+	}{
+		{
+			name: "timeouts of the paths of an Ingress",
+			ingresses: []networkingv1.Ingress{
+				ingress("web", map[string]string{"nginx.ingress.kubernetes.io/proxy-read-timeout": "300"}, "/"),
+				ingress("api", nil, "/api"),
+			},
+			expectedTimeouts: []*gatewayv1.HTTPRouteTimeouts{timeouts("5m"), nil},
+		},
+		{
+			name: "no policy emitter",
+			ingresses: []networkingv1.Ingress{ingress("web", map[string]string{
+				"nginx.ingress.kubernetes.io/proxy-connect-timeout": "10",
+				"nginx.ingress.kubernetes.io/proxy-body-size":       "8m",
+			}, "/")},
+			expectedTimeouts: []*gatewayv1.HTTPRouteTimeouts{nil},
+			expectedWarnings: 2,
+		},
+		{
+			name: "Envoy Gateway policy",
+			ingresses: []networkingv1.Ingress{ingress("web", map[string]string{
+				"nginx.ingress.kubernetes.io/proxy-connect-timeout": "10",
+				"nginx.ingress.kubernetes.io/proxy-body-size":       "8m",
+				"nginx.ingress.kubernetes.io/proxy-buffering":       "on",
+			}, "/")},
+			policyEmitter:    "envoy-gateway",
+			expectedTimeouts: []*gatewayv1.HTTPRouteTimeouts{nil},
+			expectedPolicies: []unstructured.Unstructured{backendTrafficPolicy(map[string]interface{}{
+				"timeout":       map[string]interface{}{"tcp": map[string]interface{}{"connectTimeout": "10s"}},
+				"requestBuffer": map[string]interface{}{"limit": "8Mi"},
+			})},
+			expectedWarnings: 1,
+		},
+		{
+			name: "settings differing across the paths of the HTTPRoute",
+			ingresses: []networkingv1.Ingress{
+				ingress("web", map[string]string{"nginx.ingress.kubernetes.io/proxy-body-size": "8m"}, "/"),
+				ingress("upload", map[string]string{"nginx.ingress.kubernetes.io/proxy-body-size": "1g"}, "/upload"),
+			},
+			policyEmitter:    "envoy-gateway",
+			expectedTimeouts: []*gatewayv1.HTTPRouteTimeouts{nil, nil},
+			expectedWarnings: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			gatewayResources, errs := common.ToGateway(tc.ingresses, i2gw.ProviderImplementationSpecificOptions{})
+			if len(errs) > 0 {
+				t.Fatalf("Expected no errors converting the ingresses, got %v", errs)
+			}
+
+			c := newConverter(&i2gw.ProviderConf{ProviderSpecificFlags: map[string]map[string]string{
+				Name: {PolicyEmitterFlag: tc.policyEmitter},
+			}})
+			notificationAggr := notifications.NewNotificationAggregator()
+			if errs = c.proxyFeature(tc.ingresses, &gatewayResources, notificationAggr); len(errs) > 0 {
+				t.Fatalf("Expected no errors, got %v", errs)
+			}
+
+			httpRoute := gatewayResources.HTTPRoutes[types.NamespacedName{Namespace: "default", Name: common.RouteName("web", "example.com")}]
+			var routeTimeouts []*gatewayv1.HTTPRouteTimeouts
+			for _, rule := range httpRoute.Spec.Rules {
+				routeTimeouts = append(routeTimeouts, rule.Timeouts)
+			}
+			if diff := cmp.Diff(tc.expectedTimeouts, routeTimeouts); diff != "" {
+				t.Errorf("Unexpected timeouts, diff (-want +got):\n%s", diff)
+			}
+
+			var policies []unstructured.Unstructured
+			for _, policy := range gatewayResources.Policies {
+				policies = append(policies, policy)
+			}
+			if diff := cmp.Diff(tc.expectedPolicies, policies); diff != "" {
+				t.Errorf("Unexpected policies, diff (-want +got):\n%s", diff)
+			}
+			if warnings := notificationAggr.CountAtLeast(notifications.WarningNotification); warnings != tc.expectedWarnings {
+				t.Errorf("Expected %d warnings, got %d", tc.expectedWarnings, warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressnginx
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// requestURIVariable is the nginx variable holding the path and query of the
+// request, commonly appended to redirect URLs to preserve them.
+const requestURIVariable = "$request_uri"
+
+// redirectFeature parses the temporal-redirect, permanent-redirect,
+// permanent-redirect-code and app-root annotations of the ingresses and
+// patches the rules of the HTTPRoutes with RequestRedirect filters:
+//   - the rules of the paths of an Ingress with a temporal-redirect or a
+//     permanent-redirect URL redirect all the requests to the URL instead of
+//     forwarding them to the backends. As in ingress-nginx, temporal-redirect
+//     takes precedence;
+//   - the app-root of an Ingress adds a rule redirecting the requests to the
+//     root path of its host to the app-root path.
+//
+// Anything Gateway API cannot express, e.g. status codes other than 301 and
+// 302 or nginx variables in the URL, is reported with a notification.
+func redirectFeature(ingresses []networkingv1.Ingress, gatewayResources *i2gw.GatewayResources, notificationAggr *notifications.NotificationAggregator) field.ErrorList {
+	var errs field.ErrorList
+	redirects := map[types.NamespacedName]*gatewayv1.HTTPRequestRedirectFilter{}
+	appRoots := map[types.NamespacedName]string{}
+	for i := range ingresses {
+		ingress := &ingresses[i]
+		key := types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}
+		redirect, redirectErrs := parseRedirectAnnotations(ingress, notificationAggr)
+		errs = append(errs, redirectErrs...)
+		if redirect != nil {
+			redirects[key] = redirect
+		}
+		if appRoot := ingress.Annotations[nginxAnnotation(appRootKey)]; appRoot != "" {
+			if !isValidAppRoot(appRoot) {
+				notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("ignored \"%v\" annotation %q: only absolute paths can be converted to a RequestRedirect filter", nginxAnnotation(appRootKey), appRoot), ingress)
+				continue
+			}
+			appRoots[key] = appRoot
+		}
+	}
+	if len(redirects) == 0 && len(appRoots) == 0 {
+		return errs
+	}
+
+	ruleGroups := common.GetRuleGroups(ingresses)
+	for _, rg := range ruleGroups {
+		var (
+			ruleRedirects []*gatewayv1.HTTPRequestRedirectFilter
+			appRoot       string
+		)
+		for _, ingress := range ruleIngresses(rg) {
+			ruleRedirects = append(ruleRedirects, redirects[types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}])
+		}
+		for _, rule := range rg.Rules {
+			if root, ok := appRoots[types.NamespacedName{Namespace: rule.Ingress.Namespace, Name: rule.Ingress.Name}]; ok && appRoot == "" {
+				appRoot = root
+			}
+		}
+		redirected := slices.ContainsFunc(ruleRedirects, func(redirect *gatewayv1.HTTPRequestRedirectFilter) bool { return redirect != nil })
+		if !redirected && appRoot == "" {
+			continue
+		}
+
+		key := types.NamespacedName{Namespace: rg.Namespace, Name: common.RouteName(rg.Name, rg.Host)}
+		httpRoute, ok := gatewayResources.HTTPRoutes[key]
+		if !ok {
+			errs = append(errs, field.NotFound(field.NewPath("HTTPRoute"), key))
+			continue
+		}
+
+		var redirectPatched bool
+		for i, redirect := range ruleRedirects {
+			if redirect == nil || i >= len(httpRoute.Spec.Rules) {
+				continue
+			}
+			httpRoute.Spec.Rules[i] = gatewayv1.HTTPRouteRule{
+				Matches: httpRoute.Spec.Rules[i].Matches,
+				Filters: []gatewayv1.HTTPRouteFilter{{Type: gatewayv1.HTTPRouteFilterRequestRedirect, RequestRedirect: redirect.DeepCopy()}},
+			}
+			redirectPatched = true
+		}
+		if redirectPatched {
+			notify(notificationAggr, notifications.InfoNotification, fmt.Sprintf("parsed \"%v\" and \"%v\" annotations of ingress and patched %v fields", nginxAnnotation(temporalRedirectKey), nginxAnnotation(permanentRedirectKey), field.NewPath("httproute", "spec", "rules").Key("").Child("filters")), &httpRoute)
+		}
+
+		if appRoot != "" {
+			if hasRootExactMatch(httpRoute) {
+				notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("ignored \"%v\" annotation %q: the host already has a rule for the exact path /", nginxAnnotation(appRootKey), appRoot), &httpRoute)
+			} else {
+				httpRoute.Spec.Rules = append(httpRoute.Spec.Rules, gatewayv1.HTTPRouteRule{
+					Matches: []gatewayv1.HTTPRouteMatch{{
+						Path: &gatewayv1.HTTPPathMatch{Type: ptr.To(gatewayv1.PathMatchExact), Value: ptr.To("/")},
+					}},
+					Filters: []gatewayv1.HTTPRouteFilter{{
+						Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+						RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+							Path:       &gatewayv1.HTTPPathModifier{Type: gatewayv1.FullPathHTTPPathModifier, ReplaceFullPath: ptr.To(appRoot)},
+							StatusCode: ptr.To(http.StatusFound),
+						},
+					}},
+				})
+				notify(notificationAggr, notifications.InfoNotification, fmt.Sprintf("parsed \"%v\" annotation of ingress and patched %v fields", nginxAnnotation(appRootKey), field.NewPath("httproute", "spec", "rules")), &httpRoute)
+			}
+		}
+		gatewayResources.HTTPRoutes[key] = httpRoute
+	}
+	return errs
+}
+
+// parseRedirectAnnotations returns the RequestRedirect filter of the
+// temporal-redirect or permanent-redirect URL of the ingress, if any.
+func parseRedirectAnnotations(ingress *networkingv1.Ingress, notificationAggr *notifications.NotificationAggregator) (*gatewayv1.HTTPRequestRedirectFilter, field.ErrorList) {
+	fieldPath := field.NewPath(ingress.Name).Child("metadata").Child("annotations")
+
+	annotation := nginxAnnotation(temporalRedirectKey)
+	redirectURL := ingress.Annotations[annotation]
+	statusCode := http.StatusFound
+	if redirectURL == "" {
+		annotation = nginxAnnotation(permanentRedirectKey)
+		redirectURL = ingress.Annotations[annotation]
+		if redirectURL == "" {
+			return nil, nil
+		}
+
+		statusCode = http.StatusMovedPermanently
+		if code := ingress.Annotations[nginxAnnotation(permanentRedirectCodeKey)]; code != "" {
+			// As in ingress-nginx, an invalid code falls back to 301.
+			if parsed, err := strconv.Atoi(code); err == nil {
+				statusCode = parsed
+			} else {
+				notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("\"%v\" annotation %q is not a status code, %d is used instead", nginxAnnotation(permanentRedirectCodeKey), code, http.StatusMovedPermanently), ingress)
+			}
+		}
+	}
+
+	redirect := &gatewayv1.HTTPRequestRedirectFilter{}
+	switch statusCode {
+	case http.StatusMovedPermanently, http.StatusFound:
+		redirect.StatusCode = ptr.To(statusCode)
+	case http.StatusPermanentRedirect:
+		redirect.StatusCode = ptr.To(http.StatusMovedPermanently)
+		notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("redirect status code %d is not supported by Gateway API, %d is used instead", statusCode, http.StatusMovedPermanently), ingress)
+	case http.StatusSeeOther, http.StatusTemporaryRedirect:
+		redirect.StatusCode = ptr.To(http.StatusFound)
+		notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("redirect status code %d is not supported by Gateway API, %d is used instead", statusCode, http.StatusFound), ingress)
+	default:
+		redirect.StatusCode = ptr.To(http.StatusMovedPermanently)
+		notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("\"%v\" annotation %d cannot be expressed in Gateway API, %d is used instead", nginxAnnotation(permanentRedirectCodeKey), statusCode, http.StatusMovedPermanently), ingress)
+	}
+
+	// nginx returns the URL as is, so the path of the request is replaced
+	// unless the URL ends with the request URI.
+	rawURL, keepPath := strings.CutSuffix(redirectURL, requestURIVariable)
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, field.ErrorList{field.Invalid(fieldPath.Key(annotation), redirectURL, err.Error())}
+	}
+	if strings.Contains(rawURL, "$") {
+		notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("\"%v\" annotation %q uses nginx variables that cannot be expressed in Gateway API, they are kept as is", annotation, redirectURL), ingress)
+	}
+	if u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("\"%v\" annotation %q has a query, fragment or user info that cannot be expressed in a RequestRedirect filter, they are dropped", annotation, redirectURL), ingress)
+	}
+
+	if u.Scheme != "" {
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, field.ErrorList{field.NotSupported(fieldPath.Key(annotation), u.Scheme, []string{"http", "https"})}
+		}
+		redirect.Scheme = ptr.To(u.Scheme)
+	}
+	if u.Hostname() != "" {
+		redirect.Hostname = ptr.To(gatewayv1.PreciseHostname(u.Hostname()))
+	}
+	if u.Port() != "" {
+		port, err := strconv.Atoi(u.Port())
+		if err != nil {
+			return nil, field.ErrorList{field.Invalid(fieldPath.Key(annotation), redirectURL, err.Error())}
+		}
+		redirect.Port = ptr.To(gatewayv1.PortNumber(port))
+	}
+	if !keepPath {
+		path := u.Path
+		if path == "" {
+			path = "/"
+		}
+		redirect.Path = &gatewayv1.HTTPPathModifier{Type: gatewayv1.FullPathHTTPPathModifier, ReplaceFullPath: ptr.To(path)}
+	}
+	return redirect, nil
+}
+
+// ruleIngresses returns, for every rule of the HTTPRoute of the rule group,
+// the Ingress its annotations come from. The rules are generated in the order
+// of their paths, and the first Ingress defining a path wins.
+func ruleIngresses(rg common.IngressRuleGroup) []networkingv1.Ingress {
+	var ingresses []networkingv1.Ingress
+	seenPaths := map[string]bool{}
+	for _, rule := range rg.Rules {
+		if rule.IngressRule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.IngressRule.HTTP.Paths {
+			pathKey := fmt.Sprintf("%s/%s", ptr.Deref(path.PathType, ""), path.Path)
+			if seenPaths[pathKey] {
+				continue
+			}
+			seenPaths[pathKey] = true
+			ingresses = append(ingresses, rule.Ingress)
+		}
+	}
+	return ingresses
+}
+
+// isValidAppRoot returns whether the app-root can be converted to a
+// RequestRedirect filter, i.e. whether it is an absolute path.
+func isValidAppRoot(appRoot string) bool {
+	return strings.HasPrefix(appRoot, "/") && !strings.ContainsAny(appRoot, "?#$")
+}
+
+func hasRootExactMatch(httpRoute gatewayv1.HTTPRoute) bool {
+	for _, rule := range httpRoute.Spec.Rules {
+		for _, match := range rule.Matches {
+			if match.Path != nil && ptr.Deref(match.Path.Type, "") == gatewayv1.PathMatchExact && ptr.Deref(match.Path.Value, "") == "/" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressnginx
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func Test_parseRedirectAnnotations(t *testing.T) {
+	fullPath := func(path string) *gatewayv1.HTTPPathModifier {
+		return &gatewayv1.HTTPPathModifier{Type: gatewayv1.FullPathHTTPPathModifier, ReplaceFullPath: ptr.To(path)}
+	}
+
+	testCases := []struct {
+		name             string
+		annotations      map[string]string
+		expectedRedirect *gatewayv1.HTTPRequestRedirectFilter
+		expectedWarnings int
+		expectingError   bool
+	}{
+		{
+			name: "no redirect",
+		},
+		{
+			name:        "permanent redirect to a URL",
+			annotations: map[string]string{"nginx.ingress.kubernetes.io/permanent-redirect": "https://www.example.com:8443/new"},
+			expectedRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+				Scheme:     ptr.To("https"),
+				Hostname:   ptr.To(gatewayv1.PreciseHostname("www.example.com")),
+				Port:       ptr.To(gatewayv1.PortNumber(8443)),
+				Path:       fullPath("/new"),
+				StatusCode: ptr.To(301),
+			},
+		},
+		{
+			name:        "redirect keeping the request URI",
+			annotations: map[string]string{"nginx.ingress.kubernetes.io/permanent-redirect": "https://www.example.com$request_uri"},
+			expectedRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+				Scheme:     ptr.To("https"),
+				Hostname:   ptr.To(gatewayv1.PreciseHostname("www.example.com")),
+				StatusCode: ptr.To(301),
+			},
+		},
+		{
+			name: "temporal redirect takes precedence",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/temporal-redirect":  "/maintenance",
+				"nginx.ingress.kubernetes.io/permanent-redirect": "https://www.example.com",
+			},
+			expectedRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+				Path:       fullPath("/maintenance"),
+				StatusCode: ptr.To(302),
+			},
+		},
+		{
+			name: "unsupported status code",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/permanent-redirect":      "https://www.example.com",
+				"nginx.ingress.kubernetes.io/permanent-redirect-code": "308",
+			},
+			expectedRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+				Scheme:     ptr.To("https"),
+				Hostname:   ptr.To(gatewayv1.PreciseHostname("www.example.com")),
+				Path:       fullPath("/"),
+				StatusCode: ptr.To(301),
+			},
+			expectedWarnings: 1,
+		},
+		{
+			name:        "query dropped",
+			annotations: map[string]string{"nginx.ingress.kubernetes.io/permanent-redirect": "https://www.example.com/search?q=nginx"},
+			expectedRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+				Scheme:     ptr.To("https"),
+				Hostname:   ptr.To(gatewayv1.PreciseHostname("www.example.com")),
+				Path:       fullPath("/search"),
+				StatusCode: ptr.To(301),
+			},
+			expectedWarnings: 1,
+		},
+		{
+			name: "invalid status code",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/permanent-redirect":      "https://www.example.com",
+				"nginx.ingress.kubernetes.io/permanent-redirect-code": "moved",
+			},
+			expectedRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+				Scheme:     ptr.To("https"),
+				Hostname:   ptr.To(gatewayv1.PreciseHostname("www.example.com")),
+				Path:       fullPath("/"),
+				StatusCode: ptr.To(301),
+			},
+			expectedWarnings: 1,
+		},
+		{
+			name:           "unsupported scheme",
+			annotations:    map[string]string{"nginx.ingress.kubernetes.io/permanent-redirect": "ftp://www.example.com"},
+			expectingError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", Annotations: tc.annotations}}
+			notificationAggr := notifications.NewNotificationAggregator()
+			redirect, errs := parseRedirectAnnotations(ingress, notificationAggr)
+			if tc.expectingError {
+				if len(errs) == 0 {
+					t.Fatalf("Expected errors, got none")
+				}
+				return
+			}
+			if len(errs) > 0 {
+				t.Fatalf("Expected no errors, got %v", errs)
+			}
+			if diff := cmp.Diff(tc.expectedRedirect, redirect); diff != "" {
+				t.Errorf("Unexpected redirect, diff (-want +got):\n%s", diff)
+			}
+			if warnings := notificationAggr.CountAtLeast(notifications.WarningNotification); warnings != tc.expectedWarnings {
+				t.Errorf("Expected %d warnings, got %d", tc.expectedWarnings, warnings)
+			}
+		})
+	}
+}
+
+func Test_redirectFeature(t *testing.T) {
+	ingress := func(name string, annotations map[string]string, path string) networkingv1.Ingress {
+		return networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Annotations: annotations},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: ptr.To("nginx"),
+				Rules: []networkingv1.IngressRule{{
+					Host: "example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{{
+							Path:     path,
+							PathType: ptr.To(networkingv1.PathTypePrefix),
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{Name: name, Port: networkingv1.ServiceBackendPort{Number: 80}},
+							},
+						}}},
+					},
+				}},
+			},
+		}
+	}
+	ingresses := []networkingv1.Ingress{
+		ingress("web", map[string]string{"nginx.ingress.kubernetes.io/app-root": "/app"}, "/"),
+		ingress("legacy", map[string]string{"nginx.ingress.kubernetes.io/permanent-redirect": "/new"}, "/old"),
+	}
+
+	gatewayResources, errs := common.ToGateway(ingresses, i2gw.ProviderImplementationSpecificOptions{})
+	if len(errs) > 0 {
+		t.Fatalf("Expected no errors converting the ingresses, got %v", errs)
+	}
+	if errs = redirectFeature(ingresses, &gatewayResources, notifications.NewNotificationAggregator()); len(errs) > 0 {
+		t.Fatalf("Expected no errors, got %v", errs)
+	}
+
+	pathMatch := func(matchType gatewayv1.PathMatchType, value string) []gatewayv1.HTTPRouteMatch {
+		return []gatewayv1.HTTPRouteMatch{{Path: &gatewayv1.HTTPPathMatch{Type: ptr.To(matchType), Value: ptr.To(value)}}}
+	}
+	redirect := func(path string, statusCode int) []gatewayv1.HTTPRouteFilter {
+		return []gatewayv1.HTTPRouteFilter{{
+			Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+			RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+				Path:       &gatewayv1.HTTPPathModifier{Type: gatewayv1.FullPathHTTPPathModifier, ReplaceFullPath: ptr.To(path)},
+				StatusCode: ptr.To(statusCode),
+			},
+		}}
+	}
+	expectedRules := []gatewayv1.HTTPRouteRule{
+		{
+			Matches: pathMatch(gatewayv1.PathMatchPathPrefix, "/"),
+			BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+				Name: "web",
+				Port: ptr.To(gatewayv1.PortNumber(80)),
+			}}}},
+		},
+		{
+			Matches: pathMatch(gatewayv1.PathMatchPathPrefix, "/old"),
+			Filters: redirect("/new", 301),
+		},
+		{
+			Matches: pathMatch(gatewayv1.PathMatchExact, "/"),
+			Filters: redirect("/app", 302),
+		},
+	}
+	httpRoute := gatewayResources.HTTPRoutes[types.NamespacedName{Namespace: "default", Name: common.RouteName("web", "example.com")}]
+	if diff := cmp.Diff(expectedRules, httpRoute.Spec.Rules); diff != "" {
+		t.Errorf("Unexpected HTTPRoute rules, diff (-want +got):\n%s", diff)
+	}
+}
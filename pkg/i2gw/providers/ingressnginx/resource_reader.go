@@ -44,16 +44,43 @@ func (r *resourceReader) readResourcesFromCluster(ctx context.Context) (*storage
 		return nil, err
 	}
 	storage.Ingresses.FromMap(ingresses)
+
+	services, serviceNotifications, err := common.ReadServicesFromCluster(ctx, r.conf.Client)
+	if err != nil {
+		return nil, err
+	}
+	dispatchNotification(r.conf.NotificationAggregator(), serviceNotifications)
+	storage.ServicePorts = common.GroupServicePortsByPortName(services)
+
+	configMaps, err := common.ReadConfigMapsFromCluster(ctx, r.conf.Client, r.conf.Namespace, configMapReferences(storage.Ingresses.List()))
+	if err != nil {
+		return nil, err
+	}
+	storage.ConfigMaps = configMaps
 	return storage, nil
 }
 
-func (r *resourceReader) readResourcesFromFile(filename string) (*storage, error) {
+func (r *resourceReader) readResourcesFromInputs(inputs []i2gw.Input) (*storage, error) {
 	storage := newResourcesStorage()
 
-	ingresses, err := common.ReadIngressesFromFile(filename, r.conf.Namespace, sets.New(NginxIngressClass))
+	ingresses, err := common.ReadIngressesFromInputs(inputs, r.conf.Namespace, sets.New(NginxIngressClass))
 	if err != nil {
 		return nil, err
 	}
 	storage.Ingresses.FromMap(ingresses)
+
+	services, err := common.ReadServicesFromInputs(inputs, r.conf.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	storage.ServicePorts = common.GroupServicePortsByPortName(services)
+
+	// The referenced ConfigMaps may live outside of the namespace, e.g. in
+	// the namespace of ingress-nginx.
+	configMaps, err := common.ReadConfigMapsFromInputs(inputs, "")
+	if err != nil {
+		return nil, err
+	}
+	storage.ConfigMaps = configMaps
 	return storage, nil
 }
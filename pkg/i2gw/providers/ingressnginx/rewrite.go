@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressnginx
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+var (
+	// prefixRewritePathRegex matches the paths made of a literal prefix
+	// followed by a group capturing the rest of the path, optionally preceded
+	// by a (/|$) group, e.g. /app(/|$)(.*) or /app/(.*).
+	prefixRewritePathRegex = regexp.MustCompile(`^(/[^\\()\[\]{}.*+?^$|]*)(\(/\|\$\))?\(\.\*\)$`)
+
+	// captureReferenceRegex matches the references to capture groups of a
+	// rewrite-target, e.g. $1 or ${1}.
+	captureReferenceRegex = regexp.MustCompile(`\$(\d|\{\d+\})`)
+)
+
+// implementationSpecificHTTPPathTypeMatch converts the ImplementationSpecific
+// paths to prefix matches, as ingress-nginx does unless regular expressions are
+// enabled for their host, see rewriteFeature.
+func implementationSpecificHTTPPathTypeMatch(path *gatewayv1.HTTPPathMatch) {
+	path.Type = ptr.To(gatewayv1.PathMatchPathPrefix)
+}
+
+// rewriteFeature parses the use-regex and rewrite-target annotations of the
+// ingresses and patches the matches and filters of the HTTPRoutes.
+//
+// ingress-nginx matches the Prefix and ImplementationSpecific paths of a host
+// as regular expressions as soon as an Ingress of the host sets either
+// annotation. Those paths are converted to RegularExpression matches, suffixed
+// with .* since most implementations match the whole path.
+//
+// The rewrite-target of an Ingress replaces the path of the requests matching
+// its paths:
+//   - a rewrite of the part of the path following a literal prefix, e.g. the
+//     path /app(/|$)(.*) rewritten to /$2, is converted to a PathPrefix match of
+//     the prefix with a ReplacePrefixMatch URLRewrite filter;
+//   - a rewrite without capture groups is converted to a ReplaceFullPath
+//     URLRewrite filter;
+//   - any other rewrite cannot be expressed in Gateway API and only triggers a
+//     warning.
+func rewriteFeature(ingresses []networkingv1.Ingress, gatewayResources *i2gw.GatewayResources, notificationAggr *notifications.NotificationAggregator) field.ErrorList {
+	var errs field.ErrorList
+	ruleGroups := common.GetRuleGroups(ingresses)
+	for _, rg := range ruleGroups {
+		regexEnabled := false
+		for _, rule := range rg.Rules {
+			if rule.Ingress.Annotations[nginxAnnotation(useRegexKey)] == "true" || rule.Ingress.Annotations[nginxAnnotation(rewriteTargetKey)] != "" {
+				regexEnabled = true
+			}
+		}
+		if !regexEnabled {
+			continue
+		}
+
+		key := types.NamespacedName{Namespace: rg.Namespace, Name: common.RouteName(rg.Name, rg.Host)}
+		httpRoute, ok := gatewayResources.HTTPRoutes[key]
+		if !ok {
+			errs = append(errs, field.NotFound(field.NewPath("HTTPRoute"), key))
+			continue
+		}
+
+		var regexPatched, rewritePatched bool
+		for _, rule := range rg.Rules {
+			if rule.IngressRule.HTTP == nil {
+				continue
+			}
+			ingress := rule.Ingress
+			rewriteTarget := ingress.Annotations[nginxAnnotation(rewriteTargetKey)]
+			for _, path := range rule.IngressRule.HTTP.Paths {
+				if path.PathType == nil || *path.PathType == networkingv1.PathTypeExact {
+					continue
+				}
+
+				var (
+					match  gatewayv1.HTTPPathMatch
+					filter *gatewayv1.HTTPRouteFilter
+				)
+				if prefix, replacement, ok := prefixRewrite(path.Path, rewriteTarget); ok {
+					match = gatewayv1.HTTPPathMatch{Type: ptr.To(gatewayv1.PathMatchPathPrefix), Value: ptr.To(prefix)}
+					filter = urlRewriteFilter(gatewayv1.HTTPPathModifier{Type: gatewayv1.PrefixMatchHTTPPathModifier, ReplacePrefixMatch: ptr.To(replacement)})
+				} else {
+					match = gatewayv1.HTTPPathMatch{Type: ptr.To(gatewayv1.PathMatchRegularExpression), Value: ptr.To(regexPathValue(path.Path))}
+					switch {
+					case rewriteTarget == "":
+					case !captureReferenceRegex.MatchString(rewriteTarget):
+						filter = urlRewriteFilter(gatewayv1.HTTPPathModifier{Type: gatewayv1.FullPathHTTPPathModifier, ReplaceFullPath: ptr.To(rewriteTarget)})
+					default:
+						notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("%q annotation %q of path %q uses capture groups that cannot be expressed in Gateway API, the path is matched as a regular expression but requests are not rewritten", nginxAnnotation(rewriteTargetKey), rewriteTarget, path.Path), &ingress)
+					}
+				}
+
+				if patchHTTPRouteRulePath(&httpRoute, path.Path, match, filter) {
+					regexPatched = regexPatched || *match.Type == gatewayv1.PathMatchRegularExpression
+					rewritePatched = rewritePatched || filter != nil
+				}
+			}
+		}
+
+		if regexPatched {
+			notify(notificationAggr, notifications.InfoNotification, fmt.Sprintf("parsed \"%v\" annotation of ingress and patched %v fields", nginxAnnotation(useRegexKey), field.NewPath("httproute", "spec", "rules").Key("").Child("matches")), &httpRoute)
+		}
+		if rewritePatched {
+			notify(notificationAggr, notifications.InfoNotification, fmt.Sprintf("parsed \"%v\" annotation of ingress and patched %v fields", nginxAnnotation(rewriteTargetKey), field.NewPath("httproute", "spec", "rules").Key("").Child("filters")), &httpRoute)
+		}
+		gatewayResources.HTTPRoutes[key] = httpRoute
+	}
+	return errs
+}
+
+// prefixRewrite returns the prefix of a path rewriting the part of the path
+// following it, and the replacement of the prefix, e.g. /app and /v2 for the
+// path /app(/|$)(.*) rewritten to /v2/$2.
+func prefixRewrite(path, rewriteTarget string) (string, string, bool) {
+	groups := prefixRewritePathRegex.FindStringSubmatch(path)
+	if groups == nil {
+		return "", "", false
+	}
+	captureGroup := 1
+	if groups[2] != "" {
+		captureGroup = 2
+	}
+	replacement, found := strings.CutSuffix(rewriteTarget, "/$"+strconv.Itoa(captureGroup))
+	if !found || captureReferenceRegex.MatchString(replacement) {
+		return "", "", false
+	}
+	if replacement == "" {
+		replacement = "/"
+	}
+
+	prefix := groups[1]
+	if prefix != "/" {
+		prefix = strings.TrimSuffix(prefix, "/")
+	}
+	return prefix, replacement, true
+}
+
+// regexPathValue returns the RegularExpression match of an ingress-nginx path,
+// which matches the beginning of the request path.
+func regexPathValue(path string) string {
+	if strings.HasSuffix(path, "$") || strings.HasSuffix(path, ".*") || strings.HasSuffix(path, ".*)") {
+		return path
+	}
+	return path + ".*"
+}
+
+func urlRewriteFilter(path gatewayv1.HTTPPathModifier) *gatewayv1.HTTPRouteFilter {
+	return &gatewayv1.HTTPRouteFilter{
+		Type:       gatewayv1.HTTPRouteFilterURLRewrite,
+		URLRewrite: &gatewayv1.HTTPURLRewriteFilter{Path: &path},
+	}
+}
+
+// patchHTTPRouteRulePath replaces the prefix matches of the path with the given
+// match in the rules of the HTTPRoute, and adds the filter to these rules. It
+// returns whether a rule was patched.
+func patchHTTPRouteRulePath(httpRoute *gatewayv1.HTTPRoute, path string, pathMatch gatewayv1.HTTPPathMatch, filter *gatewayv1.HTTPRouteFilter) bool {
+	patched := false
+	for i, rule := range httpRoute.Spec.Rules {
+		ruleMatched := false
+		for j, match := range rule.Matches {
+			if match.Path == nil || ptr.Deref(match.Path.Type, "") != gatewayv1.PathMatchPathPrefix || ptr.Deref(match.Path.Value, "") != path {
+				continue
+			}
+			rule.Matches[j].Path = pathMatch.DeepCopy()
+			ruleMatched = true
+		}
+		if !ruleMatched {
+			continue
+		}
+		if filter != nil {
+			rule.Filters = append(rule.Filters, *filter)
+		}
+		httpRoute.Spec.Rules[i] = rule
+		patched = true
+	}
+	return patched
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressnginx
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func Test_rewriteFeature(t *testing.T) {
+	ingress := func(name string, annotations map[string]string, pathType networkingv1.PathType, paths ...string) networkingv1.Ingress {
+		var ingressPaths []networkingv1.HTTPIngressPath
+		for _, path := range paths {
+			ingressPaths = append(ingressPaths, networkingv1.HTTPIngressPath{
+				Path:     path,
+				PathType: ptr.To(pathType),
+				Backend: networkingv1.IngressBackend{
+					Service: &networkingv1.IngressServiceBackend{Name: "app", Port: networkingv1.ServiceBackendPort{Number: 80}},
+				},
+			})
+		}
+		return networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Annotations: annotations},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: ptr.To("nginx"),
+				Rules: []networkingv1.IngressRule{{
+					Host: "example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{Paths: ingressPaths},
+					},
+				}},
+			},
+		}
+	}
+	pathMatch := func(matchType gatewayv1.PathMatchType, value string) gatewayv1.HTTPPathMatch {
+		return gatewayv1.HTTPPathMatch{Type: ptr.To(matchType), Value: ptr.To(value)}
+	}
+	rewrite := func(path gatewayv1.HTTPPathModifier) []gatewayv1.HTTPRouteFilter {
+		return []gatewayv1.HTTPRouteFilter{{Type: gatewayv1.HTTPRouteFilterURLRewrite, URLRewrite: &gatewayv1.HTTPURLRewriteFilter{Path: &path}}}
+	}
+
+	testCases := []struct {
+		name             string
+		ingresses        []networkingv1.Ingress
+		expectedMatches  []gatewayv1.HTTPPathMatch
+		expectedFilters  [][]gatewayv1.HTTPRouteFilter
+		expectedWarnings int
+	}{
+		{
+			name:            "no annotations",
+			ingresses:       []networkingv1.Ingress{ingress("app", nil, networkingv1.PathTypeImplementationSpecific, "/app")},
+			expectedMatches: []gatewayv1.HTTPPathMatch{pathMatch(gatewayv1.PathMatchPathPrefix, "/app")},
+			expectedFilters: [][]gatewayv1.HTTPRouteFilter{nil},
+		},
+		{
+			name: "regex paths of the host",
+			ingresses: []networkingv1.Ingress{
+				ingress("app", map[string]string{"nginx.ingress.kubernetes.io/use-regex": "true"}, networkingv1.PathTypeImplementationSpecific, "/app/[0-9]+"),
+				ingress("api", nil, networkingv1.PathTypePrefix, "/api/v[12]$"),
+				ingress("exact", nil, networkingv1.PathTypeExact, "/health"),
+			},
+			expectedMatches: []gatewayv1.HTTPPathMatch{
+				pathMatch(gatewayv1.PathMatchRegularExpression, "/app/[0-9]+.*"),
+				pathMatch(gatewayv1.PathMatchRegularExpression, "/api/v[12]$"),
+				pathMatch(gatewayv1.PathMatchExact, "/health"),
+			},
+			expectedFilters: [][]gatewayv1.HTTPRouteFilter{nil, nil, nil},
+		},
+		{
+			name: "prefix rewrites",
+			ingresses: []networkingv1.Ingress{
+				ingress("app", map[string]string{"nginx.ingress.kubernetes.io/rewrite-target": "/$2"}, networkingv1.PathTypeImplementationSpecific, "/app(/|$)(.*)"),
+				ingress("api", map[string]string{"nginx.ingress.kubernetes.io/rewrite-target": "/v2/$1"}, networkingv1.PathTypePrefix, "/api/(.*)"),
+			},
+			expectedMatches: []gatewayv1.HTTPPathMatch{
+				pathMatch(gatewayv1.PathMatchPathPrefix, "/app"),
+				pathMatch(gatewayv1.PathMatchPathPrefix, "/api"),
+			},
+			expectedFilters: [][]gatewayv1.HTTPRouteFilter{
+				rewrite(gatewayv1.HTTPPathModifier{Type: gatewayv1.PrefixMatchHTTPPathModifier, ReplacePrefixMatch: ptr.To("/")}),
+				rewrite(gatewayv1.HTTPPathModifier{Type: gatewayv1.PrefixMatchHTTPPathModifier, ReplacePrefixMatch: ptr.To("/v2")}),
+			},
+		},
+		{
+			name:            "rewrite without capture groups",
+			ingresses:       []networkingv1.Ingress{ingress("app", map[string]string{"nginx.ingress.kubernetes.io/rewrite-target": "/index.html"}, networkingv1.PathTypePrefix, "/app")},
+			expectedMatches: []gatewayv1.HTTPPathMatch{pathMatch(gatewayv1.PathMatchRegularExpression, "/app.*")},
+			expectedFilters: [][]gatewayv1.HTTPRouteFilter{
+				rewrite(gatewayv1.HTTPPathModifier{Type: gatewayv1.FullPathHTTPPathModifier, ReplaceFullPath: ptr.To("/index.html")}),
+			},
+		},
+		{
+			name:             "rewrite with capture groups",
+			ingresses:        []networkingv1.Ingress{ingress("app", map[string]string{"nginx.ingress.kubernetes.io/rewrite-target": "/$2/$1"}, networkingv1.PathTypeImplementationSpecific, "/([a-z]+)/([0-9]+)")},
+			expectedMatches:  []gatewayv1.HTTPPathMatch{pathMatch(gatewayv1.PathMatchRegularExpression, "/([a-z]+)/([0-9]+).*")},
+			expectedFilters:  [][]gatewayv1.HTTPRouteFilter{nil},
+			expectedWarnings: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			gatewayResources, errs := common.ToGateway(tc.ingresses, i2gw.ProviderImplementationSpecificOptions{
+				ToImplementationSpecificHTTPPathTypeMatch: implementationSpecificHTTPPathTypeMatch,
+			})
+			if len(errs) > 0 {
+				t.Fatalf("Expected no errors converting the ingresses, got %v", errs)
+			}
+
+			notificationAggr := notifications.NewNotificationAggregator()
+			if errs = rewriteFeature(tc.ingresses, &gatewayResources, notificationAggr); len(errs) > 0 {
+				t.Fatalf("Expected no errors, got %v", errs)
+			}
+
+			httpRoute := gatewayResources.HTTPRoutes[types.NamespacedName{Namespace: "default", Name: common.RouteName(tc.ingresses[0].Name, "example.com")}]
+			var (
+				matches []gatewayv1.HTTPPathMatch
+				filters [][]gatewayv1.HTTPRouteFilter
+			)
+			for _, rule := range httpRoute.Spec.Rules {
+				matches = append(matches, *rule.Matches[0].Path)
+				filters = append(filters, rule.Filters)
+			}
+			if diff := cmp.Diff(tc.expectedMatches, matches); diff != "" {
+				t.Errorf("Unexpected path matches, diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedFilters, filters); diff != "" {
+				t.Errorf("Unexpected filters, diff (-want +got):\n%s", diff)
+			}
+			if warnings := notificationAggr.CountAtLeast(notifications.WarningNotification); warnings != tc.expectedWarnings {
+				t.Errorf("Expected %d warnings, got %d", tc.expectedWarnings, warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressnginx
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// sslRedirectFeature parses the ssl-redirect and force-ssl-redirect annotations
+// of the ingresses and splits the HTTPRoutes of the redirected paths in two:
+//   - the HTTPRoute keeps its name and backends, and attaches to the HTTPS
+//     listeners only;
+//   - an HTTPRoute named <name>-http attaches to the HTTP listeners, and
+//     redirects the requests matching the redirected paths to https.
+//
+// As in ingress-nginx, the paths of the TLS hosts are redirected unless
+// ssl-redirect is false, and the paths of the other hosts when
+// force-ssl-redirect is true, provided they have an HTTPS listener to be
+// redirected to. ingress-nginx redirects with a 308 status code, which Gateway
+// API does not support, so 301 is used instead.
+//
+// The rules of the HTTPRoutes are copied as they are, so the feature must run
+// after the features patching them.
+func sslRedirectFeature(ingresses []networkingv1.Ingress, gatewayResources *i2gw.GatewayResources, notificationAggr *notifications.NotificationAggregator) field.ErrorList {
+	var errs field.ErrorList
+	ruleGroups := common.GetRuleGroups(ingresses)
+	for _, rg := range ruleGroups {
+		redirectedRules := sslRedirectedRules(rg)
+		if !slices.Contains(redirectedRules, true) {
+			continue
+		}
+
+		key := types.NamespacedName{Namespace: rg.Namespace, Name: common.RouteName(rg.Name, rg.Host)}
+		httpRoute, ok := gatewayResources.HTTPRoutes[key]
+		if !ok {
+			errs = append(errs, field.NotFound(field.NewPath("HTTPRoute"), key))
+			continue
+		}
+		redirectedRules = appendAppRootRedirectedRules(rg, httpRoute, redirectedRules)
+
+		// The parentRefs are split by listener protocol.
+		var (
+			httpParentRefs, otherParentRefs []gatewayv1.ParentReference
+			httpsAttached                   bool
+		)
+		for _, parentRef := range httpRoute.Spec.ParentRefs {
+			listeners := gatewayResources.AttachedListeners(httpRoute.Namespace, parentRef, httpRoute.Spec.Hostnames)
+			if len(listeners) == 0 {
+				otherParentRefs = append(otherParentRefs, parentRef)
+				continue
+			}
+			for _, listener := range listeners {
+				listenerRef := parentRef
+				listenerRef.SectionName = ptr.To(listener.Name)
+				if listener.Protocol == gatewayv1.HTTPProtocolType {
+					httpParentRefs = append(httpParentRefs, listenerRef)
+				} else {
+					otherParentRefs = append(otherParentRefs, listenerRef)
+					httpsAttached = httpsAttached || listener.Protocol == gatewayv1.HTTPSProtocolType
+				}
+			}
+		}
+		if len(httpParentRefs) == 0 {
+			continue
+		}
+		if !httpsAttached {
+			notify(notificationAggr, notifications.WarningNotification, fmt.Sprintf("the HTTP requests are not redirected to https as configured by the \"%v\" annotation: the host has no HTTPS listener, TLS is presumably terminated in front of ingress-nginx", nginxAnnotation(forceSSLRedirectKey)), &httpRoute)
+			continue
+		}
+
+		redirectRoute := *httpRoute.DeepCopy()
+		redirectRoute.Name = fmt.Sprintf("%s-http", httpRoute.Name)
+		redirectRoute.Spec.ParentRefs = httpParentRefs
+		for i, rule := range redirectRoute.Spec.Rules {
+			if !redirectedRules[i] {
+				continue
+			}
+			redirectRoute.Spec.Rules[i] = gatewayv1.HTTPRouteRule{
+				Matches: rule.Matches,
+				Filters: []gatewayv1.HTTPRouteFilter{{
+					Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+					RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+						Scheme:     ptr.To("https"),
+						StatusCode: ptr.To(301),
+					},
+				}},
+			}
+		}
+		redirectKey := types.NamespacedName{Namespace: redirectRoute.Namespace, Name: redirectRoute.Name}
+		if _, exists := gatewayResources.HTTPRoutes[redirectKey]; exists {
+			errs = append(errs, field.Duplicate(field.NewPath("HTTPRoute"), redirectKey))
+			continue
+		}
+
+		httpRoute.Spec.ParentRefs = otherParentRefs
+		gatewayResources.HTTPRoutes[key] = httpRoute
+		gatewayResources.HTTPRoutes[redirectKey] = redirectRoute
+		gatewayResources.AddSources(&redirectRoute, gatewayResources.Sources[i2gw.ObjectRefFor(&httpRoute)]...)
+
+		notify(notificationAggr, notifications.InfoNotification, fmt.Sprintf("parsed \"%v\" and \"%v\" annotations of ingress and redirected the HTTP requests to https with a 301 status code instead of 308", nginxAnnotation(sslRedirectKey), nginxAnnotation(forceSSLRedirectKey)), &redirectRoute)
+	}
+	return errs
+}
+
+// sslRedirectedRules returns, for every rule of the HTTPRoute of the rule
+// group converted from an ingress path, whether the requests matching it are
+// redirected to https.
+func sslRedirectedRules(rg common.IngressRuleGroup) []bool {
+	var redirectedRules []bool
+	for _, ingress := range ruleIngresses(rg) {
+		redirectedRules = append(redirectedRules, isSSLRedirected(rg, ingress))
+	}
+	return redirectedRules
+}
+
+// appendAppRootRedirectedRules returns redirectedRules completed for the rules
+// appended to the HTTPRoute after the ones converted from the ingress paths,
+// i.e. the app-root rule, which is redirected to https as the ingress whose
+// app-root it is.
+func appendAppRootRedirectedRules(rg common.IngressRuleGroup, httpRoute gatewayv1.HTTPRoute, redirectedRules []bool) []bool {
+	appRootRedirected := false
+	for _, rule := range rg.Rules {
+		if isValidAppRoot(rule.Ingress.Annotations[nginxAnnotation(appRootKey)]) {
+			appRootRedirected = isSSLRedirected(rg, rule.Ingress)
+			break
+		}
+	}
+	for len(redirectedRules) < len(httpRoute.Spec.Rules) {
+		redirectedRules = append(redirectedRules, appRootRedirected)
+	}
+	return redirectedRules
+}
+
+// isSSLRedirected returns whether the requests to the paths of the ingress in
+// the rule group are redirected to https.
+func isSSLRedirected(rg common.IngressRuleGroup, ingress networkingv1.Ingress) bool {
+	tlsHost := false
+	for _, tls := range rg.TLS {
+		if len(tls.Hosts) == 0 || slices.Contains(tls.Hosts, rg.Host) {
+			tlsHost = true
+		}
+	}
+	return ingress.Annotations[nginxAnnotation(forceSSLRedirectKey)] == "true" ||
+		(tlsHost && ingress.Annotations[nginxAnnotation(sslRedirectKey)] != "false")
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressnginx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func Test_sslRedirectFeature(t *testing.T) {
+	ingress := func(name string, annotations map[string]string, tls bool, path string) networkingv1.Ingress {
+		ingress := networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Annotations: annotations},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: ptr.To("nginx"),
+				Rules: []networkingv1.IngressRule{{
+					Host: "example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{{
+							Path:     path,
+							PathType: ptr.To(networkingv1.PathTypePrefix),
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{Name: name, Port: networkingv1.ServiceBackendPort{Number: 80}},
+							},
+						}}},
+					},
+				}},
+			},
+		}
+		if tls {
+			ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: []string{"example.com"}, SecretName: "example-com"}}
+		}
+		return ingress
+	}
+
+	testCases := []struct {
+		name      string
+		ingresses []networkingv1.Ingress
+		// expectedRoutes are the parentRef section names and, for every
+		// rule, the backend, "redirect" to https or "app-root", by HTTPRoute
+		// name.
+		expectedRoutes   map[string][]string
+		expectedWarnings int
+	}{
+		{
+			name:      "TLS host redirected by default",
+			ingresses: []networkingv1.Ingress{ingress("web", nil, true, "/")},
+			expectedRoutes: map[string][]string{
+				"web-example-com":      {"example-com-https", "web"},
+				"web-example-com-http": {"example-com-http", "redirect"},
+			},
+		},
+		{
+			name:      "redirect disabled",
+			ingresses: []networkingv1.Ingress{ingress("web", map[string]string{"nginx.ingress.kubernetes.io/ssl-redirect": "false"}, true, "/")},
+			expectedRoutes: map[string][]string{
+				"web-example-com": {"", "web"},
+			},
+		},
+		{
+			name: "paths of an Ingress not redirected",
+			ingresses: []networkingv1.Ingress{
+				ingress("web", nil, true, "/"),
+				ingress("acme", map[string]string{"nginx.ingress.kubernetes.io/ssl-redirect": "false"}, false, "/.well-known/acme-challenge"),
+			},
+			expectedRoutes: map[string][]string{
+				"web-example-com":      {"example-com-https", "web", "acme"},
+				"web-example-com-http": {"example-com-http", "redirect", "acme"},
+			},
+		},
+		{
+			name:      "app-root redirected to https",
+			ingresses: []networkingv1.Ingress{ingress("web", map[string]string{"nginx.ingress.kubernetes.io/app-root": "/app"}, true, "/app")},
+			expectedRoutes: map[string][]string{
+				"web-example-com":      {"example-com-https", "web", "app-root"},
+				"web-example-com-http": {"example-com-http", "redirect", "redirect"},
+			},
+		},
+		{
+			name:      "forced redirect of a host without HTTPS listener",
+			ingresses: []networkingv1.Ingress{ingress("web", map[string]string{"nginx.ingress.kubernetes.io/force-ssl-redirect": "true"}, false, "/")},
+			expectedRoutes: map[string][]string{
+				"web-example-com": {"", "web"},
+			},
+			expectedWarnings: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			gatewayResources, errs := common.ToGateway(tc.ingresses, i2gw.ProviderImplementationSpecificOptions{})
+			if len(errs) > 0 {
+				t.Fatalf("Expected no errors converting the ingresses, got %v", errs)
+			}
+
+			notificationAggr := notifications.NewNotificationAggregator()
+			// The app-root rule is added before the HTTPRoutes are split.
+			if errs = redirectFeature(tc.ingresses, &gatewayResources, notificationAggr); len(errs) > 0 {
+				t.Fatalf("Expected no errors, got %v", errs)
+			}
+			if errs = sslRedirectFeature(tc.ingresses, &gatewayResources, notificationAggr); len(errs) > 0 {
+				t.Fatalf("Expected no errors, got %v", errs)
+			}
+
+			routes := map[string][]string{}
+			for _, httpRoute := range gatewayResources.HTTPRoutes {
+				var sectionNames string
+				for _, parentRef := range httpRoute.Spec.ParentRefs {
+					sectionNames += string(ptr.Deref(parentRef.SectionName, ""))
+				}
+				routes[httpRoute.Name] = []string{sectionNames}
+				for _, rule := range httpRoute.Spec.Rules {
+					switch {
+					case len(rule.Filters) == 1 && rule.Filters[0].Type == gatewayv1.HTTPRouteFilterRequestRedirect && len(rule.BackendRefs) == 0:
+						if rule.Filters[0].RequestRedirect.Scheme != nil {
+							routes[httpRoute.Name] = append(routes[httpRoute.Name], "redirect")
+						} else {
+							routes[httpRoute.Name] = append(routes[httpRoute.Name], "app-root")
+						}
+					case len(rule.BackendRefs) == 1:
+						routes[httpRoute.Name] = append(routes[httpRoute.Name], string(rule.BackendRefs[0].Name))
+					default:
+						routes[httpRoute.Name] = append(routes[httpRoute.Name], fmt.Sprintf("%+v", rule))
+					}
+				}
+			}
+			if diff := cmp.Diff(tc.expectedRoutes, routes); diff != "" {
+				t.Errorf("Unexpected HTTPRoutes, diff (-want +got):\n%s", diff)
+			}
+			if warnings := notificationAggr.CountAtLeast(notifications.WarningNotification); warnings != tc.expectedWarnings {
+				t.Errorf("Expected %d warnings, got %d", tc.expectedWarnings, warnings)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package ingressnginx
 import (
 	"sort"
 
+	apiv1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -28,7 +29,11 @@ type OrderedIngressMap struct {
 	ingressObjects map[types.NamespacedName]*networkingv1.Ingress
 }
 type storage struct {
-	Ingresses OrderedIngressMap
+	Ingresses    OrderedIngressMap
+	ServicePorts map[types.NamespacedName]map[string]int32
+	// ConfigMaps are the ConfigMaps referenced by the annotations of the
+	// Ingresses, e.g. custom-headers.
+	ConfigMaps map[types.NamespacedName]*apiv1.ConfigMap
 }
 
 func newResourcesStorage() *storage {
@@ -37,6 +42,8 @@ func newResourcesStorage() *storage {
 			ingressNames:   []types.NamespacedName{},
 			ingressObjects: map[types.NamespacedName]*networkingv1.Ingress{},
 		},
+		ServicePorts: map[types.NamespacedName]map[string]int32{},
+		ConfigMaps:   map[types.NamespacedName]*apiv1.ConfigMap{},
 	}
 }
 
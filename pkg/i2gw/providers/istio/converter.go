@@ -50,10 +50,11 @@ type converter struct {
 	ctx            context.Context
 }
 
-func newConverter() converter {
+func newConverter(notificationAggr *notifications.NotificationAggregator) converter {
 	return converter{
 		gwAllowedHosts: make(map[types.NamespacedName]map[string]sets.Set[string]),
-		ctx:            context.Background(),
+		// The notifications are dispatched to the aggregator carried by the context.
+		ctx: notifications.NewContext(context.Background(), notificationAggr),
 	}
 }
 
@@ -66,6 +67,7 @@ func (c *converter) convert(storage *storage) (i2gw.GatewayResources, field.Erro
 		TLSRoutes:       make(map[types.NamespacedName]gatewayv1alpha2.TLSRoute),
 		TCPRoutes:       make(map[types.NamespacedName]gatewayv1alpha2.TCPRoute),
 		ReferenceGrants: make(map[types.NamespacedName]gatewayv1beta1.ReferenceGrant),
+		Sources:         i2gw.Sources{},
 	}
 
 	rootPath := field.NewPath(ProviderName)
@@ -81,6 +83,7 @@ func (c *converter) convert(storage *storage) (i2gw.GatewayResources, field.Erro
 			Namespace: gw.Namespace,
 			Name:      gw.Name,
 		}] = *gw
+		gatewayResources.AddSources(gw, sourceRef(GatewayKind, istioGateway.ObjectMeta))
 	}
 
 	for _, vs := range storage.VirtualServices {
@@ -94,6 +97,7 @@ func (c *converter) convert(storage *storage) (i2gw.GatewayResources, field.Erro
 		c.ctx = context.WithValue(c.ctx, virtualServiceKey, vs)
 
 		parentRefs, referenceGrants := c.generateReferences(vs, vsFieldPath)
+		source := sourceRef(VirtualServiceKind, vs.ObjectMeta)
 
 		httpRoutes, errors := c.convertVsHTTPRoutes(vs.ObjectMeta, vs.Spec.GetHttp(), vs.Spec.GetHosts(), vsFieldPath)
 		if len(errors) > 0 {
@@ -105,6 +109,7 @@ func (c *converter) convert(storage *storage) (i2gw.GatewayResources, field.Erro
 					Namespace: httpRoute.Namespace,
 					Name:      httpRoute.Name,
 				}] = *httpRoute
+				gatewayResources.AddSources(httpRoute, source)
 			}
 		}
 
@@ -114,6 +119,7 @@ func (c *converter) convert(storage *storage) (i2gw.GatewayResources, field.Erro
 				Namespace: tlsRoute.Namespace,
 				Name:      tlsRoute.Name,
 			}] = *tlsRoute
+			gatewayResources.AddSources(tlsRoute, source)
 		}
 
 		for _, tcpRoute := range c.convertVsTCPRoutes(vs.ObjectMeta, vs.Spec.GetTcp(), vsFieldPath) {
@@ -122,6 +128,7 @@ func (c *converter) convert(storage *storage) (i2gw.GatewayResources, field.Erro
 				Namespace: tcpRoute.Namespace,
 				Name:      tcpRoute.Name,
 			}] = *tcpRoute
+			gatewayResources.AddSources(tcpRoute, source)
 		}
 
 		for _, rg := range referenceGrants {
@@ -129,12 +136,22 @@ func (c *converter) convert(storage *storage) (i2gw.GatewayResources, field.Erro
 				Namespace: rg.Namespace,
 				Name:      rg.Name,
 			}] = *rg
+			gatewayResources.AddSources(rg, source)
 		}
 	}
 
 	return gatewayResources, nil
 }
 
+// sourceRef returns the ObjectRef of an Istio object, whose GroupVersionKind is
+// not set once converted to its typed struct.
+func sourceRef(kind string, objectMeta metav1.ObjectMeta) i2gw.ObjectRef {
+	return i2gw.ObjectRef{
+		GroupVersionKind: istioclientv1beta1.SchemeGroupVersion.WithKind(kind),
+		NamespacedName:   types.NamespacedName{Namespace: objectMeta.Namespace, Name: objectMeta.Name},
+	}
+}
+
 func (c *converter) convertGateway(gw *istioclientv1beta1.Gateway, fieldPath *field.Path) (*gatewayv1.Gateway, field.ErrorList) {
 	var errList field.ErrorList
 	apiVersion, kind := common.GatewayGVK.ToAPIVersionAndKind()
@@ -154,7 +171,7 @@ func (c *converter) convertGateway(gw *istioclientv1beta1.Gateway, fieldPath *fi
 
 		serverPort := server.GetPort()
 		if serverPort == nil {
-			notify(notifications.ErrorNotification, fmt.Sprintf("port is nil, path %v", serverFieldPath), gw)
+			notifyField(c.ctx, notifications.ErrorNotification, serverFieldPath, fmt.Sprintf("port is nil, path %v", serverFieldPath), gw)
 			klog.Error(field.Invalid(serverFieldPath, nil, "port is nil"))
 			continue
 		}
@@ -162,7 +179,7 @@ func (c *converter) convertGateway(gw *istioclientv1beta1.Gateway, fieldPath *fi
 		portFieldPath := serverFieldPath.Child("Port")
 
 		if serverPort.GetName() != "" {
-			notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", portFieldPath.Child("Name")), gw)
+			notifyField(c.ctx, notifications.WarningNotification, portFieldPath.Child("Name"), fmt.Sprintf("ignoring field: %v", portFieldPath.Child("Name")), gw)
 			klog.Infof("ignoring field: %v", portFieldPath.Child("Name"))
 		}
 
@@ -193,7 +210,7 @@ func (c *converter) convertGateway(gw *istioclientv1beta1.Gateway, fieldPath *fi
 			case istiov1beta1.ServerTLSSettings_SIMPLE, istiov1beta1.ServerTLSSettings_MUTUAL:
 				tlsMode = gatewayv1.TLSModeTerminate
 			case istiov1beta1.ServerTLSSettings_ISTIO_MUTUAL, istiov1beta1.ServerTLSSettings_OPTIONAL_MUTUAL:
-				notify(notifications.WarningNotification, fmt.Sprintf("the istio server is ignored as there's no direct translation for this TLS istio protocol: %v", tlsFieldPath.Child("Mode").Key(serverTLSMode.String())), gw)
+				notifyField(c.ctx, notifications.WarningNotification, tlsFieldPath.Child("Mode").Key(serverTLSMode.String()), fmt.Sprintf("the istio server is ignored as there's no direct translation for this TLS istio protocol: %v", tlsFieldPath.Child("Mode").Key(serverTLSMode.String())), gw)
 				klog.Warningf("the istio server is ignored as there's no direct translation for this TLS istio protocol: %v", tlsFieldPath.Child("Mode").Key(serverTLSMode.String()))
 				continue
 			default:
@@ -201,53 +218,53 @@ func (c *converter) convertGateway(gw *istioclientv1beta1.Gateway, fieldPath *fi
 			}
 
 			if serverTLS.GetHttpsRedirect() {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("HttpsRedirect")), gw)
+				notifyField(c.ctx, notifications.WarningNotification, tlsFieldPath.Child("HttpsRedirect"), fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("HttpsRedirect")), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("HttpsRedirect"))
 			}
 			if serverTLS.GetServerCertificate() != "" {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("ServerCertificate")), gw)
+				notifyField(c.ctx, notifications.WarningNotification, tlsFieldPath.Child("ServerCertificate"), fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("ServerCertificate")), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("ServerCertificate"))
 			}
 			if serverTLS.GetPrivateKey() != "" {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("PrivateKey")), gw)
+				notifyField(c.ctx, notifications.WarningNotification, tlsFieldPath.Child("PrivateKey"), fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("PrivateKey")), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("PrivateKey"))
 			}
 			if serverTLS.GetCaCertificates() != "" {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("CaCertificates")), gw)
+				notifyField(c.ctx, notifications.WarningNotification, tlsFieldPath.Child("CaCertificates"), fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("CaCertificates")), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("CaCertificates"))
 			}
 			if len(serverTLS.GetSubjectAltNames()) > 0 {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("SubjectAltNames")), gw)
+				notifyField(c.ctx, notifications.WarningNotification, tlsFieldPath.Child("SubjectAltNames"), fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("SubjectAltNames")), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("SubjectAltNames"))
 			}
 			if serverTLS.GetCredentialName() != "" {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("CredentialName")), gw)
+				notifyField(c.ctx, notifications.WarningNotification, tlsFieldPath.Child("CredentialName"), fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("CredentialName")), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("CredentialName"))
 			}
 			if len(serverTLS.GetVerifyCertificateSpki()) > 0 {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("VerifyCertificateSpki")), gw)
+				notifyField(c.ctx, notifications.WarningNotification, tlsFieldPath.Child("VerifyCertificateSpki"), fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("VerifyCertificateSpki")), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("VerifyCertificateSpki"))
 			}
 			if len(serverTLS.GetVerifyCertificateHash()) > 0 {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("VerifyCertificateHash")), gw)
+				notifyField(c.ctx, notifications.WarningNotification, tlsFieldPath.Child("VerifyCertificateHash"), fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("VerifyCertificateHash")), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("VerifyCertificateHash"))
 			}
 			if serverTLS.GetMinProtocolVersion() != 0 {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("MinProtocolVersion")), gw)
+				notifyField(c.ctx, notifications.WarningNotification, tlsFieldPath.Child("MinProtocolVersion"), fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("MinProtocolVersion")), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("MinProtocolVersion"))
 			}
 			if serverTLS.GetMaxProtocolVersion() != 0 {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("MaxProtocolVersion")), gw)
+				notifyField(c.ctx, notifications.WarningNotification, tlsFieldPath.Child("MaxProtocolVersion"), fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("MaxProtocolVersion")), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("MaxProtocolVersion"))
 			}
 			if len(serverTLS.GetCipherSuites()) > 0 {
-				notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("CipherSuites")), gw)
+				notifyField(c.ctx, notifications.WarningNotification, tlsFieldPath.Child("CipherSuites"), fmt.Sprintf("ignoring field: %v", tlsFieldPath.Child("CipherSuites")), gw)
 				klog.Infof("ignoring field: %v", tlsFieldPath.Child("CipherSuites"))
 			}
 		}
 
 		if server.GetBind() != "" {
-			notify(notifications.WarningNotification, fmt.Sprintf("ignoring field: %v", serverFieldPath.Child("Bind").Key(server.GetBind())), gw)
+			notifyField(c.ctx, notifications.WarningNotification, serverFieldPath.Child("Bind").Key(server.GetBind()), fmt.Sprintf("ignoring field: %v", serverFieldPath.Child("Bind").Key(server.GetBind())), gw)
 			klog.Infof("ignoring field: %v", serverFieldPath.Child("Bind").Key(server.GetBind()))
 		}
 
@@ -265,7 +282,7 @@ func (c *converter) convertGateway(gw *istioclientv1beta1.Gateway, fieldPath *fi
 			namespace, dnsName, ok := strings.Cut(host, "/")
 			if !ok {
 				// The default, if no `namespace/` is specified, is `*/`, that is, select services from any namespace.
-				notify(notifications.InfoNotification, fmt.Sprintf("no namespace specified for host \"%v\", selecting services from all namespaces", host), gw)
+				notify(c.ctx, notifications.InfoNotification, fmt.Sprintf("no namespace specified for host \"%v\", selecting services from all namespaces", host), gw)
 				namespace, dnsName = "*", host
 			}
 
@@ -320,7 +337,7 @@ func (c *converter) convertGateway(gw *istioclientv1beta1.Gateway, fieldPath *fi
 		},
 	}
 
-	notify(notifications.InfoNotification, fmt.Sprintf("successfully converted to Kubernetes Gateway \"%v/%v\"", gateway.Namespace, gateway.Name), gw)
+	notify(c.ctx, notifications.InfoNotification, fmt.Sprintf("successfully converted to Kubernetes Gateway \"%v/%v\"", gateway.Namespace, gateway.Name), gw)
 
 	return &gateway, nil
 }
@@ -336,14 +353,14 @@ func convertHostnames(ctx context.Context, hosts []string, fieldPath *field.Path
 		// '*' is valid in istio, but not in HTTPRoute
 		hostsFieldPath := fieldPath.Child("Hosts").Key(fmt.Sprintf("%v", i))
 		if !hostnameRegexp.MatchString(host) {
-			notify(notifications.WarningNotification, fmt.Sprintf("ignoring host %s, which is not allowed in Gateway API HTTPRoute, path %v", host, hostsFieldPath), vs)
+			notifyField(ctx, notifications.WarningNotification, hostsFieldPath, fmt.Sprintf("ignoring host %s, which is not allowed in Gateway API HTTPRoute, path %v", host, hostsFieldPath), vs)
 			klog.Warningf("ignoring host %s, which is not allowed in Gateway API HTTPRoute", host)
 			continue
 		}
 
 		// IP addresses are not allowed in Gateway API
 		if net.ParseIP(host) != nil {
-			notify(notifications.WarningNotification, fmt.Sprintf("ignoring host %s, which is an IP address, path %v", host, hostsFieldPath), vs)
+			notifyField(ctx, notifications.WarningNotification, hostsFieldPath, fmt.Sprintf("ignoring host %s, which is an IP address, path %v", host, hostsFieldPath), vs)
 			klog.Warningf("ignoring host %s, which is an IP address", host)
 			continue
 		}
@@ -378,39 +395,39 @@ func (c *converter) convertVsHTTPRoutes(virtualService metav1.ObjectMeta, istioH
 			httpMatchFieldPath := httpRouteFieldPath.Child("HTTPMatchRequest").Key(httpMatchFieldName)
 
 			if match.GetScheme() != nil {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("Scheme").Key(match.GetScheme().String())), vs)
+				notifyField(c.ctx, notifications.InfoNotification, httpMatchFieldPath.Child("Scheme").Key(match.GetScheme().String()), fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("Scheme").Key(match.GetScheme().String())), vs)
 				klog.Infof("ignoring field: %v", httpMatchFieldPath.Child("Scheme").Key(match.GetScheme().String()))
 			}
 			if match.GetAuthority() != nil {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("Authority").Key(match.GetAuthority().String())), vs)
+				notifyField(c.ctx, notifications.InfoNotification, httpMatchFieldPath.Child("Authority").Key(match.GetAuthority().String()), fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("Authority").Key(match.GetAuthority().String())), vs)
 				klog.Infof("ignoring field: %v", httpMatchFieldPath.Child("Authority").Key(match.GetAuthority().String()))
 			}
 			if match.GetPort() != 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("Port").Key(fmt.Sprintf("%v", match.GetPort()))), vs)
+				notifyField(c.ctx, notifications.InfoNotification, httpMatchFieldPath.Child("Port").Key(fmt.Sprintf("%v", match.GetPort())), fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("Port").Key(fmt.Sprintf("%v", match.GetPort()))), vs)
 				klog.Infof("ignoring field: %v", httpMatchFieldPath.Child("Port").Key(fmt.Sprintf("%v", match.GetPort())))
 			}
 			if len(match.GetSourceLabels()) > 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("SourceLabels")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, httpMatchFieldPath.Child("SourceLabels"), fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("SourceLabels")), vs)
 				klog.Infof("ignoring field: %v", httpMatchFieldPath.Child("SourceLabels"))
 			}
 			if match.GetIgnoreUriCase() {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("IgnoreUriCase")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, httpMatchFieldPath.Child("IgnoreUriCase"), fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("IgnoreUriCase")), vs)
 				klog.Infof("ignoring field: %v", httpMatchFieldPath.Child("IgnoreUriCase"))
 			}
 			if len(match.GetWithoutHeaders()) > 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("WithoutHeaders")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, httpMatchFieldPath.Child("WithoutHeaders"), fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("WithoutHeaders")), vs)
 				klog.Infof("ignoring field: %v", httpMatchFieldPath.Child("WithoutHeaders"))
 			}
 			if match.GetSourceNamespace() != "" {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("SourceNamespace")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, httpMatchFieldPath.Child("SourceNamespace"), fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("SourceNamespace")), vs)
 				klog.Infof("ignoring field: %v", httpMatchFieldPath.Child("SourceNamespace"))
 			}
 			if match.GetStatPrefix() != "" {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("StatPrefix")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, httpMatchFieldPath.Child("StatPrefix"), fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("StatPrefix")), vs)
 				klog.Infof("ignoring field: %v", httpMatchFieldPath.Child("StatPrefix"))
 			}
 			if len(match.GetGateways()) > 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("Gateways")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, httpMatchFieldPath.Child("Gateways"), fmt.Sprintf("ignoring field: %v", httpMatchFieldPath.Child("Gateways")), vs)
 				klog.Infof("ignoring field: %v", httpMatchFieldPath.Child("Gateways"))
 			}
 
@@ -433,7 +450,7 @@ func (c *converter) convertVsHTTPRoutes(virtualService metav1.ObjectMeta, istioH
 					matchType = gatewayv1.PathMatchRegularExpression
 					value = matchURI.GetRegex()
 				default:
-					notify(notifications.ErrorNotification, fmt.Sprintf("Unsupported Uri match type, path %v", httpMatchFieldPath.Child("Uri")), vs)
+					notifyField(c.ctx, notifications.ErrorNotification, httpMatchFieldPath.Child("Uri"), fmt.Sprintf("Unsupported Uri match type, path %v", httpMatchFieldPath.Child("Uri")), vs)
 					klog.Error(field.Invalid(httpMatchFieldPath.Child("Uri"), matchURI, "unsupported Uri match type %v"))
 				}
 
@@ -459,7 +476,7 @@ func (c *converter) convertVsHTTPRoutes(virtualService metav1.ObjectMeta, istioH
 					matchType = gatewayv1.HeaderMatchRegularExpression
 					value = headerMatch.GetRegex()
 				default:
-					notify(notifications.ErrorNotification, fmt.Sprintf("Unsupported Headers match type, path %v", httpMatchFieldPath.Child("Headers")), vs)
+					notifyField(c.ctx, notifications.ErrorNotification, httpMatchFieldPath.Child("Headers"), fmt.Sprintf("Unsupported Headers match type, path %v", httpMatchFieldPath.Child("Headers")), vs)
 					klog.Error(field.Invalid(httpMatchFieldPath.Child("Headers"), headerMatch, "unsupported Headers match type"))
 				}
 
@@ -486,7 +503,7 @@ func (c *converter) convertVsHTTPRoutes(virtualService metav1.ObjectMeta, istioH
 					matchType = gatewayv1.QueryParamMatchRegularExpression
 					value = queryMatch.GetRegex()
 				default:
-					notify(notifications.ErrorNotification, fmt.Sprintf("Unsupported QueryParams match type, path %v", httpMatchFieldPath.Child("QueryParams")), vs)
+					notifyField(c.ctx, notifications.ErrorNotification, httpMatchFieldPath.Child("QueryParams"), fmt.Sprintf("Unsupported QueryParams match type, path %v", httpMatchFieldPath.Child("QueryParams")), vs)
 					klog.Error(field.Invalid(httpMatchFieldPath.Child("QueryParams"), queryMatch, "unsupported QueryParams match type"))
 				}
 
@@ -504,7 +521,7 @@ func (c *converter) convertVsHTTPRoutes(virtualService metav1.ObjectMeta, istioH
 				case *istiov1beta1.StringMatch_Exact:
 					gwHTTPRouteMatch.Method = common.PtrTo[gatewayv1.HTTPMethod](gatewayv1.HTTPMethod(matchMethod.GetExact()))
 				default:
-					notify(notifications.ErrorNotification, fmt.Sprintf("Unsupported Method match type, path %v", httpMatchFieldPath.Child("Method")), vs)
+					notifyField(c.ctx, notifications.ErrorNotification, httpMatchFieldPath.Child("Method"), fmt.Sprintf("Unsupported Method match type, path %v", httpMatchFieldPath.Child("Method")), vs)
 					klog.Error(field.Invalid(httpMatchFieldPath.Child("Method"), matchMethod, "unsupported Method match type"))
 				}
 			}
@@ -516,7 +533,7 @@ func (c *converter) convertVsHTTPRoutes(virtualService metav1.ObjectMeta, istioH
 			routeDestinationFieldPath := httpRouteFieldPath.Child("HTTPRouteDestination").Index(j)
 
 			if routeDestination.GetHeaders() != nil {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", routeDestinationFieldPath.Child("Headers")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, routeDestinationFieldPath.Child("Headers"), fmt.Sprintf("ignoring field: %v", routeDestinationFieldPath.Child("Headers")), vs)
 				klog.Infof("ignoring field: %v", routeDestinationFieldPath.Child("Headers"))
 			}
 
@@ -535,11 +552,11 @@ func (c *converter) convertVsHTTPRoutes(virtualService metav1.ObjectMeta, istioH
 			redirectFieldPath := httpRouteFieldPath.Child("HTTPRedirect")
 
 			if routeRedirect.GetAuthority() != "" {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", redirectFieldPath.Child("Authority")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, redirectFieldPath.Child("Authority"), fmt.Sprintf("ignoring field: %v", redirectFieldPath.Child("Authority")), vs)
 				klog.Infof("ignoring field: %v", redirectFieldPath.Child("Authority"))
 			}
 			if _, ok := routeRedirect.GetRedirectPort().(*istiov1beta1.HTTPRedirect_DerivePort); ok {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", redirectFieldPath.Child("DerivePort")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, redirectFieldPath.Child("DerivePort"), fmt.Sprintf("ignoring field: %v", redirectFieldPath.Child("DerivePort")), vs)
 				klog.Infof("ignoring field: %v", redirectFieldPath.Child("DerivePort"))
 			}
 
@@ -578,23 +595,23 @@ func (c *converter) convertVsHTTPRoutes(virtualService metav1.ObjectMeta, istioH
 		}
 
 		if httpRoute.GetDirectResponse() != nil {
-			notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpRouteFieldPath.Child("DirectResponse")), vs)
+			notifyField(c.ctx, notifications.InfoNotification, httpRouteFieldPath.Child("DirectResponse"), fmt.Sprintf("ignoring field: %v", httpRouteFieldPath.Child("DirectResponse")), vs)
 			klog.Infof("ignoring field: %v", httpRouteFieldPath.Child("DirectResponse"))
 		}
 		if httpRoute.GetDelegate() != nil {
-			notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpRouteFieldPath.Child("Delegate")), vs)
+			notifyField(c.ctx, notifications.InfoNotification, httpRouteFieldPath.Child("Delegate"), fmt.Sprintf("ignoring field: %v", httpRouteFieldPath.Child("Delegate")), vs)
 			klog.Infof("ignoring field: %v", httpRouteFieldPath.Child("Delegate"))
 		}
 		if httpRoute.GetRetries() != nil {
-			notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpRouteFieldPath.Child("Retries")), vs)
+			notifyField(c.ctx, notifications.InfoNotification, httpRouteFieldPath.Child("Retries"), fmt.Sprintf("ignoring field: %v", httpRouteFieldPath.Child("Retries")), vs)
 			klog.Infof("ignoring field: %v", httpRouteFieldPath.Child("Retries"))
 		}
 		if httpRoute.GetFault() != nil {
-			notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpRouteFieldPath.Child("Fault")), vs)
+			notifyField(c.ctx, notifications.InfoNotification, httpRouteFieldPath.Child("Fault"), fmt.Sprintf("ignoring field: %v", httpRouteFieldPath.Child("Fault")), vs)
 			klog.Infof("ignoring field: %v", httpRouteFieldPath.Child("Fault"))
 		}
 		if httpRoute.GetCorsPolicy() != nil {
-			notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", httpRouteFieldPath.Child("CorsPolicy")), vs)
+			notifyField(c.ctx, notifications.InfoNotification, httpRouteFieldPath.Child("CorsPolicy"), fmt.Sprintf("ignoring field: %v", httpRouteFieldPath.Child("CorsPolicy")), vs)
 			klog.Infof("ignoring field: %v", httpRouteFieldPath.Child("CorsPolicy"))
 		}
 
@@ -621,7 +638,7 @@ func (c *converter) convertVsHTTPRoutes(virtualService metav1.ObjectMeta, istioH
 			routeDestinationFieldPath := httpRouteFieldPath.Child("Mirrors").Index(j)
 
 			if mirror.GetPercentage() != nil {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", routeDestinationFieldPath.Child("Percentage")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, routeDestinationFieldPath.Child("Percentage"), fmt.Sprintf("ignoring field: %v", routeDestinationFieldPath.Child("Percentage")), vs)
 				klog.Infof("ignoring field: %v", routeDestinationFieldPath.Child("Percentage"))
 			}
 
@@ -693,14 +710,14 @@ func (c *converter) convertVsHTTPRoutes(virtualService metav1.ObjectMeta, istioH
 			httpRoutesWithRewrites := c.createHTTPRoutesWithRewrite(createHTTPRouteParams, httpRoute.GetRewrite(), httpRouteFieldPath.Child("HTTPRewrite"))
 			resHTTPRoutes = append(resHTTPRoutes, httpRoutesWithRewrites...)
 			for _, httpRoute := range httpRoutesWithRewrites {
-				notify(notifications.InfoNotification, fmt.Sprintf("successfully converted to HTTPRoute \"%v/%v\"", httpRoute.Namespace, httpRoute.Name), vs)
+				notify(c.ctx, notifications.InfoNotification, fmt.Sprintf("successfully converted to HTTPRoute \"%v/%v\"", httpRoute.Namespace, httpRoute.Name), vs)
 			}
 			continue
 		}
 
 		httpRoute := c.createHTTPRoute(createHTTPRouteParams)
 		resHTTPRoutes = append(resHTTPRoutes, httpRoute)
-		notify(notifications.InfoNotification, fmt.Sprintf("successfully converted to HTTPRoute \"%v/%v\"", httpRoute.Namespace, httpRoute.Name), vs)
+		notify(c.ctx, notifications.InfoNotification, fmt.Sprintf("successfully converted to HTTPRoute \"%v/%v\"", httpRoute.Namespace, httpRoute.Name), vs)
 	}
 
 	if len(errList) > 0 {
@@ -761,11 +778,11 @@ func (c *converter) createHTTPRoutesWithRewrite(params createHTTPRouteParams, re
 	}
 
 	if rewrite.GetAuthority() != "" {
-		notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", fieldPath.Child("Authority")), vs)
+		notifyField(c.ctx, notifications.InfoNotification, fieldPath.Child("Authority"), fmt.Sprintf("ignoring field: %v", fieldPath.Child("Authority")), vs)
 		klog.Infof("ignoring field: %v", fieldPath.Child("Authority"))
 	}
 	if rewrite.GetUriRegexRewrite() != nil {
-		notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", fieldPath.Child("UriRegexRewrite")), vs)
+		notifyField(c.ctx, notifications.InfoNotification, fieldPath.Child("UriRegexRewrite"), fmt.Sprintf("ignoring field: %v", fieldPath.Child("UriRegexRewrite")), vs)
 		klog.Infof("ignoring field: %v", fieldPath.Child("UriRegexRewrite"))
 	}
 
@@ -853,23 +870,23 @@ func (c *converter) convertVsTLSRoutes(virtualService metav1.ObjectMeta, istioTL
 			tlsMatchFieldPath := tlsRouteFieldPath.Child("TLSMatchAttributes").Index(j)
 
 			if len(match.GetDestinationSubnets()) > 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tlsMatchFieldPath.Child("DestinationSubnets")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, tlsMatchFieldPath.Child("DestinationSubnets"), fmt.Sprintf("ignoring field: %v", tlsMatchFieldPath.Child("DestinationSubnets")), vs)
 				klog.Infof("ignoring field: %v", tlsMatchFieldPath.Child("DestinationSubnets"))
 			}
 			if match.GetPort() != 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tlsMatchFieldPath.Child("Port")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, tlsMatchFieldPath.Child("Port"), fmt.Sprintf("ignoring field: %v", tlsMatchFieldPath.Child("Port")), vs)
 				klog.Infof("ignoring field: %v", tlsMatchFieldPath.Child("Port"))
 			}
 			if len(match.GetSourceLabels()) > 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tlsMatchFieldPath.Child("SourceLabels")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, tlsMatchFieldPath.Child("SourceLabels"), fmt.Sprintf("ignoring field: %v", tlsMatchFieldPath.Child("SourceLabels")), vs)
 				klog.Infof("ignoring field: %v", tlsMatchFieldPath.Child("SourceLabels"))
 			}
 			if len(match.GetGateways()) > 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tlsMatchFieldPath.Child("Gateways")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, tlsMatchFieldPath.Child("Gateways"), fmt.Sprintf("ignoring field: %v", tlsMatchFieldPath.Child("Gateways")), vs)
 				klog.Infof("ignoring field: %v", tlsMatchFieldPath.Child("Gateways"))
 			}
 			if match.GetSourceNamespace() != "" {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tlsMatchFieldPath.Child("SourceNamespace")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, tlsMatchFieldPath.Child("SourceNamespace"), fmt.Sprintf("ignoring field: %v", tlsMatchFieldPath.Child("SourceNamespace")), vs)
 				klog.Infof("ignoring field: %v", tlsMatchFieldPath.Child("SourceNamespace"))
 			}
 		}
@@ -901,7 +918,7 @@ func (c *converter) convertVsTLSRoutes(virtualService metav1.ObjectMeta, istioTL
 			},
 		}
 		resTLSRoutes = append(resTLSRoutes, tlsRoute)
-		notify(notifications.InfoNotification, fmt.Sprintf("successfully converted to TLSRoute \"%v/%v\"", tlsRoute.Namespace, tlsRoute.Name), vs)
+		notify(c.ctx, notifications.InfoNotification, fmt.Sprintf("successfully converted to TLSRoute \"%v/%v\"", tlsRoute.Namespace, tlsRoute.Name), vs)
 	}
 
 	return resTLSRoutes
@@ -929,27 +946,27 @@ func (c *converter) convertVsTCPRoutes(virtualService metav1.ObjectMeta, istioTC
 			tcpMatchFieldPath := tcpRouteFieldPath.Child("L4MatchAttributes").Index(j)
 
 			if len(match.GetDestinationSubnets()) > 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tcpMatchFieldPath.Child("DestinationSubnets")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, tcpMatchFieldPath.Child("DestinationSubnets"), fmt.Sprintf("ignoring field: %v", tcpMatchFieldPath.Child("DestinationSubnets")), vs)
 				klog.Infof("ignoring field: %v", tcpMatchFieldPath.Child("DestinationSubnets"))
 			}
 			if match.GetPort() != 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tcpMatchFieldPath.Child("Port")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, tcpMatchFieldPath.Child("Port"), fmt.Sprintf("ignoring field: %v", tcpMatchFieldPath.Child("Port")), vs)
 				klog.Infof("ignoring field: %v", tcpMatchFieldPath.Child("Port"))
 			}
 			if match.GetSourceSubnet() != "" {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tcpMatchFieldPath.Child("SourceSubnet")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, tcpMatchFieldPath.Child("SourceSubnet"), fmt.Sprintf("ignoring field: %v", tcpMatchFieldPath.Child("SourceSubnet")), vs)
 				klog.Infof("ignoring field: %v", tcpMatchFieldPath.Child("SourceSubnet"))
 			}
 			if len(match.GetSourceLabels()) > 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tcpMatchFieldPath.Child("SourceLabels")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, tcpMatchFieldPath.Child("SourceLabels"), fmt.Sprintf("ignoring field: %v", tcpMatchFieldPath.Child("SourceLabels")), vs)
 				klog.Infof("ignoring field: %v", tcpMatchFieldPath.Child("SourceLabels"))
 			}
 			if match.GetSourceNamespace() != "" {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tcpMatchFieldPath.Child("SourceNamespace")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, tcpMatchFieldPath.Child("SourceNamespace"), fmt.Sprintf("ignoring field: %v", tcpMatchFieldPath.Child("SourceNamespace")), vs)
 				klog.Infof("ignoring field: %v", tcpMatchFieldPath.Child("SourceNamespace"))
 			}
 			if len(match.GetGateways()) > 0 {
-				notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", tcpMatchFieldPath.Child("Gateways")), vs)
+				notifyField(c.ctx, notifications.InfoNotification, tcpMatchFieldPath.Child("Gateways"), fmt.Sprintf("ignoring field: %v", tcpMatchFieldPath.Child("Gateways")), vs)
 				klog.Infof("ignoring field: %v", tcpMatchFieldPath.Child("Gateways"))
 			}
 		}
@@ -980,7 +997,7 @@ func (c *converter) convertVsTCPRoutes(virtualService metav1.ObjectMeta, istioTC
 			},
 		}
 		resTCPRoutes = append(resTCPRoutes, tcpRoute)
-		notify(notifications.InfoNotification, fmt.Sprintf("successfully converted to TCPRoute \"%v/%v\"", tcpRoute.Namespace, tcpRoute.Name), vs)
+		notify(c.ctx, notifications.InfoNotification, fmt.Sprintf("successfully converted to TCPRoute \"%v/%v\"", tcpRoute.Namespace, tcpRoute.Name), vs)
 	}
 
 	return resTCPRoutes
@@ -995,14 +1012,14 @@ func (c *converter) isVirtualServiceAllowedForGateway(gateway types.NamespacedNa
 
 	isAllowedNamespace := vsAllowedNamespaces.HasAny(gateway.Namespace, "*") || (vsAllowedNamespaces.Has(".") && vs.Namespace == gateway.Namespace)
 	if !isAllowedNamespace {
-		notify(notifications.WarningNotification, fmt.Sprintf("gateway from vs.Spec.Gateways %q is not visible in vs.ExportTo %v, parentRefs are not generated for this host, path: %v", gateway.String(), vs.Spec.GetExportTo(), fieldPath), vs)
+		notifyField(c.ctx, notifications.WarningNotification, fieldPath, fmt.Sprintf("gateway from vs.Spec.Gateways %q is not visible in vs.ExportTo %v, parentRefs are not generated for this host, path: %v", gateway.String(), vs.Spec.GetExportTo(), fieldPath), vs)
 		klog.Warningf("gateway from vs.Spec.Gateways %q is not visible in vs.ExportTo %v, parentRefs are not generated for this host, path: %v", gateway.String(), vs.Spec.GetExportTo(), fieldPath)
 		return false
 	}
 
 	allowedHosts, ok := c.gwAllowedHosts[gateway]
 	if !ok {
-		notify(notifications.WarningNotification, fmt.Sprintf("no info about gateway %v allowed hosts, parentRefs won't be generated to it, path: %v", gateway.String(), fieldPath), vs)
+		notifyField(c.ctx, notifications.WarningNotification, fieldPath, fmt.Sprintf("no info about gateway %v allowed hosts, parentRefs won't be generated to it, path: %v", gateway.String(), fieldPath), vs)
 		klog.Warningf("no info about gateway %v allowed hosts, parentRefs won't be generated to it, path: %v", gateway.String(), fieldPath)
 		return false
 	}
@@ -1010,24 +1027,24 @@ func (c *converter) isVirtualServiceAllowedForGateway(gateway types.NamespacedNa
 	for _, host := range vs.Spec.GetHosts() {
 		hosts, ok := allowedHosts[vs.Namespace]
 		if ok && matchAny(hosts.UnsortedList(), host) {
-			notify(notifications.InfoNotification, fmt.Sprintf("host for gateway \"%v\" matched from same namespace as VirtualService \"%v\", namesapce: %v", gateway, vs.Name, vs.Namespace), vs)
+			notify(c.ctx, notifications.InfoNotification, fmt.Sprintf("host for gateway \"%v\" matched from same namespace as VirtualService \"%v\", namesapce: %v", gateway, vs.Name, vs.Namespace), vs)
 			return true
 		}
 
 		hosts, ok = allowedHosts["."]
 		if ok && vs.Namespace == gateway.Namespace && matchAny(hosts.UnsortedList(), host) {
-			notify(notifications.InfoNotification, fmt.Sprintf("host for gateway \"%v\" matched from the current namespace", gateway), vs)
+			notify(c.ctx, notifications.InfoNotification, fmt.Sprintf("host for gateway \"%v\" matched from the current namespace", gateway), vs)
 			return true
 		}
 
 		hosts, ok = allowedHosts["*"]
 		if ok && matchAny(hosts.UnsortedList(), host) {
-			notify(notifications.InfoNotification, fmt.Sprintf("host for gateway \"%v\" matched from all namespaces", gateway), vs)
+			notify(c.ctx, notifications.InfoNotification, fmt.Sprintf("host for gateway \"%v\" matched from all namespaces", gateway), vs)
 			return true
 		}
 	}
 
-	notify(notifications.WarningNotification, fmt.Sprintf("no host in vs.Spec.Hosts matched any gateway.allowedHosts, parentRefs are not generated for this VirtualService, path: %v", fieldPath), vs)
+	notifyField(c.ctx, notifications.WarningNotification, fieldPath, fmt.Sprintf("no host in vs.Spec.Hosts matched any gateway.allowedHosts, parentRefs are not generated for this VirtualService, path: %v", fieldPath), vs)
 	klog.Warningf("no host in vs.Spec.Hosts matched any gateway.allowedHosts, parentRefs are not generated for this VirtualService, path: %v", fieldPath)
 	return false
 }
@@ -1056,7 +1073,7 @@ func (c *converter) generateReferences(vs *istioclientv1beta1.VirtualService, fi
 		}
 
 		if !ok {
-			notify(notifications.InfoNotification, fmt.Sprintf("namespace of \"%v\" gateway taken from namesapce of VirtualService", gwName), vs)
+			notify(c.ctx, notifications.InfoNotification, fmt.Sprintf("namespace of \"%v\" gateway taken from namesapce of VirtualService", gwName), vs)
 		}
 
 		g := gatewayv1.Group(common.GatewayGVK.Group)
@@ -1081,11 +1098,11 @@ func (c *converter) generateReferences(vs *istioclientv1beta1.VirtualService, fi
 			})
 
 			referenceGrants = append(referenceGrants, referenceGrant)
-			notify(notifications.InfoNotification, fmt.Sprintf("successfully created reference grant from %v to %v namespace", vs.Namespace, gateway.Namespace), vs, referenceGrant)
+			notify(c.ctx, notifications.InfoNotification, fmt.Sprintf("successfully created reference grant from %v to %v namespace", vs.Namespace, gateway.Namespace), vs, referenceGrant)
 		}
 
 		parentRefs = append(parentRefs, parentRef)
-		notify(notifications.InfoNotification, fmt.Sprintf("generated new Parent Reference %v", parentRef.Name), vs)
+		notify(c.ctx, notifications.InfoNotification, fmt.Sprintf("generated new Parent Reference %v", parentRef.Name), vs)
 	}
 
 	return parentRefs, referenceGrants
@@ -1169,13 +1186,13 @@ func parseK8SServiceFromDomain(domain string, fallbackNamespace string) (string,
 func destination2backendObjRef(ctx context.Context, destination *istiov1beta1.Destination, vsNamespace string, fieldPath *field.Path) *gatewayv1.BackendObjectReference {
 	vs := ctx.Value(virtualServiceKey).(*istioclientv1beta1.VirtualService)
 	if destination == nil {
-		notify(notifications.InfoNotification, fmt.Sprintf("destination is nil: %v", fieldPath), vs)
+		notifyField(ctx, notifications.InfoNotification, fieldPath, fmt.Sprintf("destination is nil: %v", fieldPath), vs)
 		klog.Infof("destination is nil: %v", fieldPath)
 		return nil
 	}
 
 	if destination.GetSubset() != "" {
-		notify(notifications.InfoNotification, fmt.Sprintf("ignoring field: %v", fieldPath.Child("Destination", "Subset")), vs)
+		notifyField(ctx, notifications.InfoNotification, fieldPath.Child("Destination", "Subset"), fmt.Sprintf("ignoring field: %v", fieldPath.Child("Destination", "Subset")), vs)
 		klog.Infof("ignoring field: %v", fieldPath.Child("Destination", "Subset"))
 	}
 
@@ -27,6 +27,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -49,9 +50,14 @@ func TestFileConversion(t *testing.T) {
 			return nil
 		}
 
-		istioProvider := NewProvider(&i2gw.ProviderConf{})
+		istioProvider := NewProvider(&i2gw.ProviderConf{Notifications: notifications.NewNotificationAggregator()})
 
-		err = istioProvider.ReadResourcesFromFile(ctx, path)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read file %v: %v", d.Name(), err.Error())
+		}
+
+		err = istioProvider.ReadResourcesFromInputs(ctx, []i2gw.Input{{Name: path, Data: data}})
 		if err != nil {
 			t.Fatalf("Failed to read input from file %v: %v", d.Name(), err.Error())
 		}
@@ -42,7 +42,7 @@ func NewProvider(conf *i2gw.ProviderConf) i2gw.Provider {
 	return &Provider{
 		storage:   newResourcesStorage(),
 		reader:    newResourceReader(conf),
-		converter: newConverter(),
+		converter: newConverter(conf.NotificationAggregator()),
 	}
 }
 
@@ -62,10 +62,10 @@ func (p *Provider) ReadResourcesFromCluster(ctx context.Context) error {
 	return nil
 }
 
-func (p *Provider) ReadResourcesFromFile(ctx context.Context, filename string) error {
-	storage, err := p.reader.readResourcesFromFile(ctx, filename)
+func (p *Provider) ReadResourcesFromInputs(ctx context.Context, inputs []i2gw.Input) error {
+	storage, err := p.reader.readResourcesFromInputs(ctx, inputs)
 	if err != nil {
-		return fmt.Errorf("failed to read resources from file: %w", err)
+		return fmt.Errorf("failed to read resources from the inputs: %w", err)
 	}
 	p.storage = storage
 	return nil
@@ -17,11 +17,20 @@ limitations under the License.
 package istio
 
 import (
+	"context"
+
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func notify(mType notifications.MessageType, message string, callingObject ...client.Object) {
+// notify dispatches a notification to the aggregator carried by ctx.
+func notify(ctx context.Context, mType notifications.MessageType, message string, callingObject ...client.Object) {
 	newNotification := notifications.NewNotification(mType, message, callingObject...)
-	notifications.NotificationAggr.DispatchNotification(newNotification, string(ProviderName))
+	notifications.FromContext(ctx).DispatchNotification(newNotification, string(ProviderName))
+}
+
+func notifyField(ctx context.Context, mType notifications.MessageType, fieldPath *field.Path, message string, callingObject ...client.Object) {
+	newNotification := notifications.NewFieldNotification(mType, fieldPath, message, callingObject...)
+	notifications.FromContext(ctx).DispatchNotification(newNotification, string(ProviderName))
 }
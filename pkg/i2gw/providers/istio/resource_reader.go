@@ -17,11 +17,9 @@ limitations under the License.
 package istio
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"log"
-	"os"
 
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
@@ -61,15 +59,10 @@ func (r *reader) readResourcesFromCluster(ctx context.Context) (*storage, error)
 	return res, nil
 }
 
-func (r *reader) readResourcesFromFile(_ context.Context, filename string) (*storage, error) {
-	stream, err := os.ReadFile(filename)
+func (r *reader) readResourcesFromInputs(_ context.Context, inputs []i2gw.Input) (*storage, error) {
+	unstructuredObjects, err := common.ExtractObjectsFromInputs(inputs, r.conf.Namespace)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file %v: %w", filename, err)
-	}
-
-	unstructuredObjects, err := common.ExtractObjectsFromReader(bytes.NewReader(stream), r.conf.Namespace)
-	if err != nil {
-		return nil, fmt.Errorf("failed to extract objects: %w", err)
+		return nil, err
 	}
 
 	storage, err := r.readUnstructuredObjects(unstructuredObjects)
@@ -124,7 +117,7 @@ func (r *reader) readGatewaysFromCluster(ctx context.Context) (map[types.Namespa
 	gatewayList.SetAPIVersion(APIVersion)
 	gatewayList.SetKind(GatewayKind)
 
-	err := r.conf.Client.List(ctx, gatewayList)
+	err := common.ListAll(ctx, r.conf.Client, gatewayList)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list istio gateways: %w", err)
 	}
@@ -150,7 +143,7 @@ func (r *reader) readVirtualServicesFromCluster(ctx context.Context) (map[types.
 	virtualServicesList.SetAPIVersion(APIVersion)
 	virtualServicesList.SetKind(VirtualServiceKind)
 
-	err := r.conf.Client.List(ctx, virtualServicesList)
+	err := common.ListAll(ctx, r.conf.Client, virtualServicesList)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list istio virtual services: %w", err)
 	}
@@ -27,13 +27,16 @@ import (
 
 // converter implements the ToGatewayAPI function of i2gw.ResourceConverter interface.
 type converter struct {
+	conf *i2gw.ProviderConf
+
 	featureParsers                []i2gw.FeatureParser
 	implementationSpecificOptions i2gw.ProviderImplementationSpecificOptions
 }
 
 // newConverter returns an kong converter instance.
-func newConverter() *converter {
+func newConverter(conf *i2gw.ProviderConf) *converter {
 	return &converter{
+		conf: conf,
 		featureParsers: []i2gw.FeatureParser{
 			headerMatchingFeature,
 			methodMatchingFeature,
@@ -41,6 +44,8 @@ func newConverter() *converter {
 		},
 		implementationSpecificOptions: i2gw.ProviderImplementationSpecificOptions{
 			ToImplementationSpecificHTTPPathTypeMatch: implementationSpecificHTTPPathTypeMatch,
+			ListenerConsolidation:                     conf.ListenerConsolidation,
+			GatewayNamespace:                          conf.GatewayNamespace,
 		},
 	}
 }
@@ -51,6 +56,9 @@ func (c *converter) convert(storage *storage) (i2gw.GatewayResources, field.Erro
 		ingressList = append(ingressList, *ingress)
 	}
 
+	ingressList, notificationsAggregator := common.ResolveNamedServicePorts(ingressList, storage.ServicePorts)
+	dispatchNotification(c.conf.NotificationAggregator(), notificationsAggregator)
+
 	errorList := field.ErrorList{}
 
 	// Convert plain ingress resources to gateway resources, ignoring all
@@ -65,7 +73,7 @@ func (c *converter) convert(storage *storage) (i2gw.GatewayResources, field.Erro
 		errorList = append(errorList, errs...)
 	}
 
-	dispatchNotification(notificationsAggregator)
+	dispatchNotification(c.conf.NotificationAggregator(), notificationsAggregator)
 
 	if len(errorList) > 0 {
 		return i2gw.GatewayResources{}, errorList
@@ -78,7 +86,7 @@ func (c *converter) convert(storage *storage) (i2gw.GatewayResources, field.Erro
 
 	for _, parseFeatureFunc := range c.featureParsers {
 		// Apply the feature parsing function to the gateway resources, one by one.
-		errs = parseFeatureFunc(ingressList, &gatewayResources)
+		errs = parseFeatureFunc(ingressList, &gatewayResources, c.conf.NotificationAggregator())
 		// Append the parsing errors to the error list.
 		errorList = append(errorList, errs...)
 	}
@@ -17,6 +17,8 @@ limitations under the License.
 package crds
 
 import (
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+
 	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
 )
 
@@ -24,6 +26,9 @@ type ruleGroupKey string
 
 type tcpIngressAggregator struct {
 	ruleGroups map[ruleGroupKey]*tcpIngressRuleGroup
+
+	// sources maps the generated objects to the TCPIngresses they come from.
+	sources i2gw.Sources
 }
 
 type tcpIngressRuleGroup struct {
@@ -34,6 +39,7 @@ type tcpIngressRuleGroup struct {
 	port         int
 	tls          []kongv1beta1.IngressTLS
 	rules        []ingressRule
+	sources      []i2gw.ObjectRef
 }
 
 type ingressRule struct {
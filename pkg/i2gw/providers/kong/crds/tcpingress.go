@@ -18,6 +18,7 @@ package crds
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -36,7 +37,10 @@ import (
 
 // TCPIngressToGatewayAPI converts the received TCPingresses to i2gw.GatewayResources,
 func TCPIngressToGatewayAPI(ingresses []kongv1beta1.TCPIngress) (i2gw.GatewayResources, []notifications.Notification, field.ErrorList) {
-	aggregator := tcpIngressAggregator{ruleGroups: map[ruleGroupKey]*tcpIngressRuleGroup{}}
+	aggregator := tcpIngressAggregator{
+		ruleGroups: map[ruleGroupKey]*tcpIngressRuleGroup{},
+		sources:    i2gw.Sources{},
+	}
 	var notificationsAggregator []notifications.Notification
 
 	var errs field.ErrorList
@@ -65,15 +69,20 @@ func TCPIngressToGatewayAPI(ingresses []kongv1beta1.TCPIngress) (i2gw.GatewayRes
 	}
 
 	gatewayByKey := make(map[types.NamespacedName]gatewayv1.Gateway)
+	// The Gateways are named after the ingress class of their TCPIngresses.
+	sourceClasses := make(map[types.NamespacedName]string)
 	for _, gateway := range gateways {
 		key := types.NamespacedName{Namespace: gateway.Namespace, Name: gateway.Name}
 		gatewayByKey[key] = gateway
+		sourceClasses[key] = gateway.Name
 	}
 
 	return i2gw.GatewayResources{
-		Gateways:  gatewayByKey,
-		TCPRoutes: tcpRouteByKey,
-		TLSRoutes: tlsRouteByKey,
+		Gateways:      gatewayByKey,
+		TCPRoutes:     tcpRouteByKey,
+		TLSRoutes:     tlsRouteByKey,
+		Sources:       aggregator.sources,
+		SourceClasses: sourceClasses,
 	}, notificationsAggregator, nil
 }
 
@@ -110,6 +119,13 @@ func (a *tcpIngressAggregator) addIngressRule(namespace, name, ingressClass stri
 		rg.tls = append(rg.tls, iSpec.TLS...)
 	}
 	rg.rules = append(rg.rules, ingressRule{rule: rule})
+	source := i2gw.ObjectRef{
+		GroupVersionKind: kongv1beta1.SchemeGroupVersion.WithKind("TCPIngress"),
+		NamespacedName:   types.NamespacedName{Namespace: namespace, Name: name},
+	}
+	if !slices.Contains(rg.sources, source) {
+		rg.sources = append(rg.sources, source)
+	}
 }
 
 func (a *tcpIngressAggregator) toRoutesAndGateways() ([]gatewayv1alpha2.TCPRoute, []gatewayv1alpha2.TLSRoute, []gatewayv1.Gateway, field.ErrorList) {
@@ -118,6 +134,7 @@ func (a *tcpIngressAggregator) toRoutesAndGateways() ([]gatewayv1alpha2.TCPRoute
 
 	var errors field.ErrorList
 	listenersByNamespacedGateway := map[string][]gatewayv1.Listener{}
+	sourcesByNamespacedGateway := map[string][]i2gw.ObjectRef{}
 
 	for _, rg := range a.ruleGroups {
 		listener := gatewayv1.Listener{}
@@ -142,11 +159,16 @@ func (a *tcpIngressAggregator) toRoutesAndGateways() ([]gatewayv1alpha2.TCPRoute
 		}
 		gwKey := fmt.Sprintf("%s/%s", rg.namespace, rg.ingressClass)
 		listenersByNamespacedGateway[gwKey] = append(listenersByNamespacedGateway[gwKey], listener)
+		sourcesByNamespacedGateway[gwKey] = append(sourcesByNamespacedGateway[gwKey], rg.sources...)
 		var errs field.ErrorList
 		if listener.TLS == nil {
-			tcpRoutes = append(tcpRoutes, rg.toTCPRoute())
+			tcpRoute := rg.toTCPRoute()
+			tcpRoutes = append(tcpRoutes, tcpRoute)
+			a.sources.Add(i2gw.ObjectRefFor(&tcpRoute), rg.sources...)
 		} else {
-			tlsRoutes = append(tlsRoutes, rg.toTLSRoute())
+			tlsRoute := rg.toTLSRoute()
+			tlsRoutes = append(tlsRoutes, tlsRoute)
+			a.sources.Add(i2gw.ObjectRefFor(&tlsRoute), rg.sources...)
 		}
 		errors = append(errors, errs...)
 	}
@@ -172,6 +194,7 @@ func (a *tcpIngressAggregator) toRoutesAndGateways() ([]gatewayv1alpha2.TCPRoute
 			gateway.SetGroupVersionKind(common.GatewayGVK)
 			gatewaysByKey[gwKey] = gateway
 		}
+		a.sources.Add(i2gw.ObjectRefFor(gateway), sourcesByNamespacedGateway[gwKey]...)
 		for _, listener := range listeners {
 			hostname := ""
 			if listener.Hostname != nil {
@@ -43,7 +43,7 @@ type Provider struct {
 func NewProvider(conf *i2gw.ProviderConf) i2gw.Provider {
 	return &Provider{
 		resourceReader: newResourceReader(conf),
-		converter:      newConverter(),
+		converter:      newConverter(conf),
 	}
 }
 
@@ -62,8 +62,8 @@ func (p *Provider) ReadResourcesFromCluster(ctx context.Context) error {
 	return nil
 }
 
-func (p *Provider) ReadResourcesFromFile(_ context.Context, filename string) error {
-	storage, err := p.readResourcesFromFile(filename)
+func (p *Provider) ReadResourcesFromInputs(_ context.Context, inputs []i2gw.Input) error {
+	storage, err := p.readResourcesFromInputs(inputs)
 	if err != nil {
 		return err
 	}
@@ -21,13 +21,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func notify(mType notifications.MessageType, message string, callingObject ...client.Object) {
+func notify(notificationAggr *notifications.NotificationAggregator, mType notifications.MessageType, message string, callingObject ...client.Object) {
 	newNotification := notifications.NewNotification(mType, message, callingObject...)
-	notifications.NotificationAggr.DispatchNotification(newNotification, string(Name))
+	notificationAggr.DispatchNotification(newNotification, string(Name))
 }
 
-func dispatchNotification(n []notifications.Notification) {
+func dispatchNotification(notificationAggr *notifications.NotificationAggregator, n []notifications.Notification) {
 	for _, v := range n {
-		notify(v.Type, v.Message, v.CallingObjects...)
+		notificationAggr.DispatchNotification(v, string(Name))
 	}
 }
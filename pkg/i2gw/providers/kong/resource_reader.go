@@ -17,10 +17,8 @@ limitations under the License.
 package kong
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"os"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -56,6 +54,13 @@ func (r *resourceReader) readResourcesFromCluster(ctx context.Context) (*storage
 	}
 	storage.Ingresses = ingresses
 
+	services, serviceNotifications, err := common.ReadServicesFromCluster(ctx, r.conf.Client)
+	if err != nil {
+		return nil, err
+	}
+	dispatchNotification(r.conf.NotificationAggregator(), serviceNotifications)
+	storage.ServicePorts = common.GroupServicePortsByPortName(services)
+
 	tcpIngresses, err := r.readTCPIngressesFromCluster(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read TCPIngresses: %w", err)
@@ -65,16 +70,22 @@ func (r *resourceReader) readResourcesFromCluster(ctx context.Context) (*storage
 	return storage, nil
 }
 
-func (r *resourceReader) readResourcesFromFile(filename string) (*storage, error) {
+func (r *resourceReader) readResourcesFromInputs(inputs []i2gw.Input) (*storage, error) {
 	storage := newResourceStorage()
 
-	ingresses, err := common.ReadIngressesFromFile(filename, r.conf.Namespace, sets.New(KongIngressClass))
+	ingresses, err := common.ReadIngressesFromInputs(inputs, r.conf.Namespace, sets.New(KongIngressClass))
 	if err != nil {
 		return nil, err
 	}
 	storage.Ingresses = ingresses
 
-	tcpIngresses, err := r.readTCPIngressesFromFile(filename)
+	services, err := common.ReadServicesFromInputs(inputs, r.conf.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	storage.ServicePorts = common.GroupServicePortsByPortName(services)
+
+	tcpIngresses, err := r.readTCPIngressesFromInputs(inputs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read TCPIngresses: %w", err)
 	}
@@ -91,7 +102,7 @@ func (r *resourceReader) readTCPIngressesFromCluster(ctx context.Context) ([]kon
 	tcpIngressList := &unstructured.UnstructuredList{}
 	tcpIngressList.SetGroupVersionKind(tcpIngressGVK)
 
-	err := r.conf.Client.List(ctx, tcpIngressList)
+	err := common.ListAll(ctx, r.conf.Client, tcpIngressList)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list %s: %w", tcpIngressGVK.GroupKind().String(), err)
 	}
@@ -109,14 +120,8 @@ func (r *resourceReader) readTCPIngressesFromCluster(ctx context.Context) ([]kon
 	return tcpIngresses, nil
 }
 
-func (r *resourceReader) readTCPIngressesFromFile(filename string) ([]kongv1beta1.TCPIngress, error) {
-	stream, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, err
-	}
-
-	reader := bytes.NewReader(stream)
-	objs, err := common.ExtractObjectsFromReader(reader, r.conf.Namespace)
+func (r *resourceReader) readTCPIngressesFromInputs(inputs []i2gw.Input) ([]kongv1beta1.TCPIngress, error) {
+	objs, err := common.ExtractObjectsFromInputs(inputs, r.conf.Namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -36,6 +36,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/notifications"
 	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
 )
 
@@ -51,6 +52,7 @@ func TestFileConvertion(t *testing.T) {
 
 	defaultTestData := testData{
 		providerConf: &i2gw.ProviderConf{
+			Notifications: notifications.NewNotificationAggregator(),
 			ProviderSpecificFlags: map[string]map[string]string{
 				"openapi3": {
 					"gateway-class-name": "external",
@@ -64,7 +66,8 @@ func TestFileConvertion(t *testing.T) {
 	customTestData := map[string]testData{
 		"reference-grants.yaml": {
 			providerConf: &i2gw.ProviderConf{
-				Namespace: "networking",
+				Notifications: notifications.NewNotificationAggregator(),
+				Namespace:     "networking",
 				ProviderSpecificFlags: map[string]map[string]string{
 					"openapi3": {
 						"gateway-class-name": "external",
@@ -75,7 +78,7 @@ func TestFileConvertion(t *testing.T) {
 			},
 		},
 		"invalid-spec.yaml": {
-			expectedReadFileError: fmt.Errorf("failed to read resources from file: invalid OpenAPI 3.x spec"),
+			expectedReadFileError: fmt.Errorf("invalid OpenAPI 3.x spec"),
 		},
 	}
 
@@ -103,7 +106,12 @@ func TestFileConvertion(t *testing.T) {
 
 		provider := NewProvider(providerConf)
 
-		if readFileErr := provider.ReadResourcesFromFile(ctx, path); readFileErr != nil {
+		input, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read test file %v: %v", d.Name(), err.Error())
+		}
+
+		if readFileErr := provider.ReadResourcesFromInputs(ctx, []i2gw.Input{{Name: path, Data: input}}); readFileErr != nil {
 			if expectedReadFileError == nil {
 				t.Fatalf("unexpected error during reading test file %v: %v", d.Name(), readFileErr.Error())
 			} else if !strings.Contains(readFileErr.Error(), expectedReadFileError.Error()) {
@@ -229,3 +237,57 @@ func readGatewayResourcesFromFile(t *testing.T, filename string) (*i2gw.GatewayR
 
 	return &res, nil
 }
+
+func TestMultipleSpecs(t *testing.T) {
+	files := []string{"1-petstore3.yaml", "2-hostnames.yaml"}
+
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, filepath.Join(fixturesDir, "input", file))
+	}
+	inputs, err := common.ReadInputFiles(paths, strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("unexpected error reading the input files: %v", err)
+	}
+
+	provider := NewProvider(&i2gw.ProviderConf{
+		Notifications: notifications.NewNotificationAggregator(),
+		ProviderSpecificFlags: map[string]map[string]string{
+			"openapi3": {
+				"gateway-class-name": "external",
+				"gateway-tls-secret": "gateway-tls-cert",
+				"backend":            "backend-1:3000",
+			},
+		},
+	})
+	if err := provider.ReadResourcesFromInputs(context.Background(), inputs); err != nil {
+		t.Fatalf("unexpected error reading the specs: %v", err)
+	}
+
+	gotGatewayResources, errList := provider.ToGatewayAPI()
+	if len(errList) > 0 {
+		t.Fatalf("unexpected errors during conversion: %v", errList.ToAggregate().Error())
+	}
+
+	wantGateways := map[types.NamespacedName]gatewayv1.Gateway{}
+	wantHTTPRoutes := map[types.NamespacedName]gatewayv1.HTTPRoute{}
+	for _, file := range files {
+		wantGatewayResources, err := readGatewayResourcesFromFile(t, filepath.Join(fixturesDir, "output", file))
+		if err != nil {
+			t.Fatalf("failed to read wantGatewayResources from file %v: %v", file, err.Error())
+		}
+		for key, gateway := range wantGatewayResources.Gateways {
+			wantGateways[key] = gateway
+		}
+		for key, httpRoute := range wantGatewayResources.HTTPRoutes {
+			wantHTTPRoutes[key] = httpRoute
+		}
+	}
+
+	if !apiequality.Semantic.DeepEqual(gotGatewayResources.Gateways, wantGateways) {
+		t.Errorf("Gateways diff (-want +got): %s", cmp.Diff(wantGateways, gotGatewayResources.Gateways))
+	}
+	if !apiequality.Semantic.DeepEqual(gotGatewayResources.HTTPRoutes, wantHTTPRoutes) {
+		t.Errorf("HTTPRoutes diff (-want +got): %s", cmp.Diff(wantHTTPRoutes, gotGatewayResources.HTTPRoutes))
+	}
+}
@@ -19,6 +19,7 @@ package openapi3
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -74,15 +75,18 @@ func (p *Provider) ReadResourcesFromCluster(_ context.Context) error {
 	return nil
 }
 
-// ReadResourcesFromFile reads OpenAPI specs from a JSON or YAML file.
-func (p *Provider) ReadResourcesFromFile(ctx context.Context, filename string) error {
-	spec, err := readSpecFromFile(ctx, filename)
-	if err != nil {
-		return fmt.Errorf("failed to read resources from file: %w", err)
-	}
-
+// ReadResourcesFromInputs reads an OpenAPI spec from every JSON or YAML input.
+// The inputs given as decoded objects are skipped.
+func (p *Provider) ReadResourcesFromInputs(ctx context.Context, inputs []i2gw.Input) error {
 	p.storage.Clear()
-	if spec != nil {
+	for _, input := range inputs {
+		if input.Objects != nil {
+			continue
+		}
+		spec, err := readSpec(ctx, input)
+		if err != nil {
+			return fmt.Errorf("failed to read resources from %v: %w", input.Name, err)
+		}
 		p.storage.AddResource(spec)
 	}
 
@@ -94,9 +98,11 @@ func (p *Provider) ToGatewayAPI() (i2gw.GatewayResources, field.ErrorList) {
 	return p.converter.Convert(p.storage)
 }
 
-func readSpecFromFile(ctx context.Context, filename string) (*openapi3.T, error) {
+func readSpec(ctx context.Context, input i2gw.Input) (*openapi3.T, error) {
 	loader := openapi3.NewLoader()
-	spec, err := loader.LoadFromFile(filename)
+	// The name of the input is its location, which the relative references of
+	// the spec are resolved from.
+	spec, err := loader.LoadFromDataWithPath(input.Data, &url.URL{Path: input.Name})
 	if err != nil {
 		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
 	}
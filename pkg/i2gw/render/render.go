@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package render renders Helm charts and kustomize overlays locally, so that
+// the resulting manifests can be used as conversion input.
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/getter"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultHelmReleaseName is the release name used to render Helm charts,
+// the same as `helm template`.
+const DefaultHelmReleaseName = "release-name"
+
+// HelmChart renders the chart in chartDir like `helm template` does, merging
+// the values files in order, and sets the release namespace on the namespaced
+// objects rendered without one. Only the chart dependencies vendored in the
+// charts/ directory are used, and no cluster nor repository is contacted.
+func HelmChart(chartDir string, valuesFiles []string, releaseName, namespace string) ([]byte, error) {
+	chart, err := loader.Load(chartDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Helm chart %v: %w", chartDir, err)
+	}
+	if err = action.CheckDependencies(chart, chart.Metadata.Dependencies); err != nil {
+		return nil, fmt.Errorf("missing dependencies in Helm chart %v, run `helm dependency build` first: %w", chartDir, err)
+	}
+
+	// No getter is provided, so that values files are always read from the disk.
+	vals, err := (&values.Options{ValueFiles: valuesFiles}).MergeValues(getter.Providers{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Helm values: %w", err)
+	}
+
+	install := action.NewInstall(&action.Configuration{Log: func(string, ...interface{}) {}})
+	install.DryRun = true
+	install.ClientOnly = true
+	install.Replace = true
+	install.IncludeCRDs = true
+	install.ReleaseName = releaseName
+	install.Namespace = namespace
+
+	release, err := install.Run(chart, vals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render Helm chart %v: %w", chartDir, err)
+	}
+
+	manifests, err := setDefaultNamespace([]byte(release.Manifest), namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set the release namespace in Helm chart %v: %w", chartDir, err)
+	}
+	return manifests, nil
+}
+
+// clusterScopedKinds are the kinds of the common cluster-scoped objects. As
+// charts are rendered without cluster, the scope of the other kinds is unknown
+// and they are assumed to be namespaced.
+var clusterScopedKinds = sets.New(
+	"APIService",
+	"ClusterIssuer",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"CSIDriver",
+	"CustomResourceDefinition",
+	"GatewayClass",
+	"IngressClass",
+	"MutatingWebhookConfiguration",
+	"Namespace",
+	"PersistentVolume",
+	"PriorityClass",
+	"RuntimeClass",
+	"StorageClass",
+	"ValidatingWebhookConfiguration",
+)
+
+// setDefaultNamespace sets the namespace of the namespaced objects without
+// one, like the release namespace is applied to them on `helm install`.
+func setDefaultNamespace(manifests []byte, namespace string) ([]byte, error) {
+	nodes, err := (&kio.ByteReader{Reader: bytes.NewReader(manifests), OmitReaderAnnotations: true}).Read()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range nodes {
+		if node.GetNamespace() != "" || clusterScopedKinds.Has(node.GetKind()) {
+			continue
+		}
+		if err = node.SetNamespace(namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	if err = (kio.ByteWriter{Writer: &out}).Write(nodes); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// Kustomization builds the kustomization in dir like `kustomize build` does.
+// Only local kustomizations are built: the ones referring to remote resources,
+// such as git repositories or URLs, are refused, so that the build never
+// reaches the network.
+func Kustomization(dir string) ([]byte, error) {
+	fSys := filesys.MakeFsOnDisk()
+	if err := checkLocalKustomization(fSys, dir, sets.New[string]()); err != nil {
+		return nil, fmt.Errorf("failed to build kustomization %v: %w", dir, err)
+	}
+
+	resources, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(fSys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kustomization %v: %w", dir, err)
+	}
+
+	manifests, err := resources.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode kustomization %v: %w", dir, err)
+	}
+	return manifests, nil
+}
+
+// checkLocalKustomization returns an error when the kustomization in dir, or
+// one of the kustomizations it refers to, refers to files or directories that
+// do not exist locally, e.g. remote resources. The directories in visited are
+// already checked.
+func checkLocalKustomization(fSys filesys.FileSystem, dir string, visited sets.Set[string]) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if visited.Has(dir) {
+		return nil
+	}
+	visited.Insert(dir)
+
+	var data []byte
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		if data, err = fSys.ReadFile(filepath.Join(dir, name)); err == nil {
+			break
+		}
+	}
+	// Invalid kustomizations are reported by the build.
+	var kustomization types.Kustomization
+	if data == nil || yaml.Unmarshal(data, &kustomization) != nil {
+		return nil
+	}
+
+	var refs []string
+	for _, kustomizationRefs := range [][]string{kustomization.Resources, kustomization.Bases, kustomization.Components, kustomization.Crds,
+		kustomization.Configurations, kustomization.Generators, kustomization.Transformers, kustomization.Validators} {
+		refs = append(refs, kustomizationRefs...)
+	}
+	for _, ref := range refs {
+		// Generators, transformers and validators may be inlined.
+		if strings.Contains(ref, "\n") {
+			continue
+		}
+		path := ref
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		if !fSys.Exists(path) {
+			return fmt.Errorf("%q is not a local file or directory, remote resources are not supported", ref)
+		}
+		if fSys.IsDir(path) {
+			if err := checkLocalKustomization(fSys, path, visited); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubernetes-sigs/ingress2gateway/pkg/i2gw/providers/common"
+)
+
+const ingressTemplate = `apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: {{ .Release.Name }}-web
+  namespace: {{ .Release.Namespace }}
+spec:
+  ingressClassName: {{ .Values.ingressClassName }}
+  rules:
+  - host: {{ .Values.host }}
+`
+
+const ingressManifest = `apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+spec:
+  ingressClassName: nginx
+`
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestHelmChart(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"chart/Chart.yaml":             "apiVersion: v2\nname: web\nversion: 0.1.0\n",
+		"chart/values.yaml":            "ingressClassName: nginx\nhost: example.com\n",
+		"chart/templates/ingress.yaml": ingressTemplate,
+		"prod-values.yaml":             "host: prod.example.com\n",
+	})
+
+	manifests, err := HelmChart(filepath.Join(dir, "chart"), []string{filepath.Join(dir, "prod-values.yaml")}, "prod", "web")
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+
+	objects, err := common.ExtractObjectsFromReader(bytes.NewReader(manifests), "")
+	if err != nil {
+		t.Fatalf("Expected rendered manifests to be decoded but got %v", err)
+	}
+	if len(objects) != 1 {
+		t.Fatalf("Expected 1 object, got %d", len(objects))
+	}
+	if objects[0].GetName() != "prod-web" || objects[0].GetNamespace() != "web" {
+		t.Errorf("Expected Ingress web/prod-web, got %s/%s", objects[0].GetNamespace(), objects[0].GetName())
+	}
+	if !bytes.Contains(manifests, []byte("prod.example.com")) {
+		t.Errorf("Expected values file to override the chart values, got:\n%s", manifests)
+	}
+}
+
+func TestHelmChartReleaseNamespace(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"Chart.yaml":                   "apiVersion: v2\nname: web\nversion: 0.1.0\n",
+		"templates/ingress.yaml":       ingressManifest,
+		"templates/ingressclass.yaml":  "apiVersion: networking.k8s.io/v1\nkind: IngressClass\nmetadata:\n  name: nginx\n",
+		"templates/other-ingress.yaml": "apiVersion: networking.k8s.io/v1\nkind: Ingress\nmetadata:\n  name: other\n  namespace: other\n",
+	})
+
+	manifests, err := HelmChart(dir, nil, DefaultHelmReleaseName, "prod")
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+
+	objects, err := common.ExtractObjectsFromReader(bytes.NewReader(manifests), "")
+	if err != nil {
+		t.Fatalf("Expected rendered manifests to be decoded but got %v", err)
+	}
+	namespaces := map[string]string{}
+	for _, obj := range objects {
+		namespaces[obj.GetKind()+"/"+obj.GetName()] = obj.GetNamespace()
+	}
+	expected := map[string]string{
+		"Ingress/web":        "prod",
+		"Ingress/other":      "other",
+		"IngressClass/nginx": "",
+	}
+	if diff := cmp.Diff(expected, namespaces); diff != "" {
+		t.Errorf("Unexpected object namespaces (-want +got):\n%s", diff)
+	}
+}
+
+func TestHelmChartMissingDependencies(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"Chart.yaml": "apiVersion: v2\nname: web\nversion: 0.1.0\ndependencies:\n- name: common\n  version: 1.0.0\n  repository: https://charts.example.com\n",
+	})
+
+	if _, err := HelmChart(dir, nil, DefaultHelmReleaseName, "default"); err == nil {
+		t.Errorf("Expected an error for a chart with missing dependencies")
+	}
+}
+
+func TestKustomization(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"base/kustomization.yaml":    "resources:\n- ingress.yaml\n",
+		"base/ingress.yaml":          ingressManifest,
+		"overlay/kustomization.yaml": "namespace: prod\nnamePrefix: prod-\nresources:\n- ../base\n",
+	})
+
+	manifests, err := Kustomization(filepath.Join(dir, "overlay"))
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+
+	objects, err := common.ExtractObjectsFromReader(bytes.NewReader(manifests), "")
+	if err != nil {
+		t.Fatalf("Expected built manifests to be decoded but got %v", err)
+	}
+	if len(objects) != 1 {
+		t.Fatalf("Expected 1 object, got %d", len(objects))
+	}
+	if objects[0].GetName() != "prod-web" || objects[0].GetNamespace() != "prod" {
+		t.Errorf("Expected Ingress prod/prod-web, got %s/%s", objects[0].GetNamespace(), objects[0].GetName())
+	}
+}
+
+func TestKustomizationRemoteResources(t *testing.T) {
+	testCases := []struct {
+		name     string
+		resource string
+	}{
+		{
+			name:     "git repository",
+			resource: "github.com/kubernetes-sigs/ingress2gateway//examples?ref=main",
+		},
+		{
+			name:     "URL",
+			resource: "https://example.com/ingress.yaml",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			// The remote resource is referred to by a local base.
+			writeFiles(t, dir, map[string]string{
+				"base/kustomization.yaml":    "resources:\n- ingress.yaml\n- " + tc.resource + "\n",
+				"base/ingress.yaml":          ingressManifest,
+				"overlay/kustomization.yaml": "resources:\n- ../base\n",
+			})
+
+			_, err := Kustomization(filepath.Join(dir, "overlay"))
+			if err == nil {
+				t.Fatalf("Expected an error")
+			}
+			if !strings.Contains(err.Error(), "remote resources are not supported") {
+				t.Errorf("Expected the remote resource to be refused, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+const (
+	// MaxListenersPerGateway is the maximum number of listeners of a Gateway.
+	MaxListenersPerGateway = 64
+
+	// maxAddressesPerGateway is the maximum number of addresses of a Gateway.
+	maxAddressesPerGateway = 16
+
+	// maxParentRefsPerRoute is the maximum number of parentRefs of a route.
+	maxParentRefsPerRoute = 32
+)
+
+// ShardGateways splits every Gateway with more than maxListeners listeners
+// into Gateways named <name>-1 to <name>-N, with the same spec but at most
+// maxListeners listeners each. The addresses are kept by the first shard only,
+// so that they are not claimed by several Gateways. The listeners of a
+// hostname are kept in the same shard whenever they fit, and the parentRefs of
+// the routes attached to a split Gateway are rewritten to reference the
+// listeners they attach to in the shards holding them, or the shards as a
+// whole when there would be too many parentRefs. An error is returned for the
+// routes attached to too many shards all the same.
+//
+// Nothing is split when maxListeners is not positive.
+func ShardGateways(gatewayResources *GatewayResources, maxListeners int) field.ErrorList {
+	if maxListeners <= 0 {
+		return nil
+	}
+
+	var errs field.ErrorList
+	shardsByGateway := map[types.NamespacedName][]gatewayv1.Gateway{}
+	for _, nn := range sortedKeys(gatewayResources.Gateways) {
+		g := gatewayResources.Gateways[nn]
+		if len(g.Spec.Listeners) <= maxListeners {
+			continue
+		}
+
+		var (
+			shards []gatewayv1.Gateway
+			exists bool
+		)
+		for i, listeners := range shardListeners(g.Spec.Listeners, maxListeners) {
+			shard := *g.DeepCopy()
+			shard.Name = fmt.Sprintf("%s-%d", g.Name, i+1)
+			shard.Spec.Listeners = listeners
+			if i > 0 {
+				// The addresses are claimed by the first shard only.
+				shard.Spec.Addresses = nil
+			}
+			if _, ok := gatewayResources.Gateways[client.ObjectKeyFromObject(&shard)]; ok {
+				fieldPath := field.NewPath(fmt.Sprintf("%s/%s", nn.Namespace, nn.Name))
+				errs = append(errs, field.Invalid(fieldPath, nn.String(), fmt.Sprintf("cannot split the Gateway, Gateway %s/%s already exists", shard.Namespace, shard.Name)))
+				exists = true
+				break
+			}
+			shards = append(shards, shard)
+		}
+		if exists {
+			continue
+		}
+
+		sources := gatewayResources.Sources[ObjectRefFor(&g)]
+		delete(gatewayResources.Sources, ObjectRefFor(&g))
+		delete(gatewayResources.Gateways, nn)
+		for _, shard := range shards {
+			gatewayResources.Gateways[client.ObjectKeyFromObject(&shard)] = shard
+			gatewayResources.AddSources(&shard, sources...)
+		}
+		shardsByGateway[nn] = shards
+	}
+	if len(errs) > 0 || len(shardsByGateway) == 0 {
+		return errs
+	}
+
+	httpProtocols := []gatewayv1.ProtocolType{gatewayv1.HTTPProtocolType, gatewayv1.HTTPSProtocolType}
+	errs = append(errs, shardRoutes(gatewayResources.HTTPRoutes, func(r *gatewayv1.HTTPRoute) (*gatewayv1.CommonRouteSpec, []gatewayv1.Hostname) {
+		return &r.Spec.CommonRouteSpec, r.Spec.Hostnames
+	}, httpProtocols, shardsByGateway)...)
+	errs = append(errs, shardRoutes(gatewayResources.TLSRoutes, func(r *gatewayv1alpha2.TLSRoute) (*gatewayv1.CommonRouteSpec, []gatewayv1.Hostname) {
+		return &r.Spec.CommonRouteSpec, r.Spec.Hostnames
+	}, []gatewayv1.ProtocolType{gatewayv1.TLSProtocolType}, shardsByGateway)...)
+	errs = append(errs, shardRoutes(gatewayResources.TCPRoutes, func(r *gatewayv1alpha2.TCPRoute) (*gatewayv1.CommonRouteSpec, []gatewayv1.Hostname) {
+		return &r.Spec.CommonRouteSpec, nil
+	}, []gatewayv1.ProtocolType{gatewayv1.TCPProtocolType}, shardsByGateway)...)
+	errs = append(errs, shardRoutes(gatewayResources.UDPRoutes, func(r *gatewayv1alpha2.UDPRoute) (*gatewayv1.CommonRouteSpec, []gatewayv1.Hostname) {
+		return &r.Spec.CommonRouteSpec, nil
+	}, []gatewayv1.ProtocolType{gatewayv1.UDPProtocolType}, shardsByGateway)...)
+
+	return errs
+}
+
+// shardListeners splits the listeners in groups of at most maxListeners
+// listeners, keeping the listeners of a hostname in the same group when they
+// fit in it.
+func shardListeners(listeners []gatewayv1.Listener, maxListeners int) [][]gatewayv1.Listener {
+	var (
+		hostnames           []string
+		listenersByHostname = map[string][]gatewayv1.Listener{}
+	)
+	for _, listener := range listeners {
+		hostname := ""
+		if listener.Hostname != nil {
+			hostname = string(*listener.Hostname)
+		}
+		if _, ok := listenersByHostname[hostname]; !ok {
+			hostnames = append(hostnames, hostname)
+		}
+		listenersByHostname[hostname] = append(listenersByHostname[hostname], listener)
+	}
+
+	var shards [][]gatewayv1.Listener
+	for _, hostname := range hostnames {
+		group := listenersByHostname[hostname]
+		if len(shards) == 0 || len(shards[len(shards)-1])+len(group) > maxListeners {
+			shards = append(shards, nil)
+		}
+		for _, listener := range group {
+			if len(shards[len(shards)-1]) == maxListeners {
+				shards = append(shards, nil)
+			}
+			shards[len(shards)-1] = append(shards[len(shards)-1], listener)
+		}
+	}
+	return shards
+}
+
+// shardRoutes rewrites the parentRefs of the routes referencing a split
+// Gateway, see shardParentRefs. When referencing every listener takes more than
+// maxParentRefsPerRoute parentRefs, the shards are referenced as a whole
+// instead, and an error is returned for the routes still exceeding it.
+func shardRoutes[T any, PT interface {
+	*T
+	client.Object
+}](routes map[types.NamespacedName]T, spec func(PT) (*gatewayv1.CommonRouteSpec, []gatewayv1.Hostname), protocols []gatewayv1.ProtocolType, shardsByGateway map[types.NamespacedName][]gatewayv1.Gateway) field.ErrorList {
+	var errs field.ErrorList
+	for _, nn := range sortedKeys(routes) {
+		route := routes[nn]
+		commonSpec, hostnames := spec(&route)
+
+		parentRefs := shardParentRefs(nn.Namespace, commonSpec.ParentRefs, hostnames, protocols, shardsByGateway, true)
+		if len(parentRefs) > maxParentRefsPerRoute {
+			parentRefs = shardParentRefs(nn.Namespace, commonSpec.ParentRefs, hostnames, protocols, shardsByGateway, false)
+		}
+		if len(parentRefs) > maxParentRefsPerRoute {
+			fieldPath := field.NewPath(fmt.Sprintf("%s/%s", nn.Namespace, nn.Name)).Child("spec").Child("parentRefs")
+			errs = append(errs, field.TooMany(fieldPath, len(parentRefs), maxParentRefsPerRoute))
+		}
+
+		commonSpec.ParentRefs = parentRefs
+		routes[nn] = route
+	}
+	return errs
+}
+
+// shardParentRefs returns the parentRefs of a route in namespace, with the
+// parentRefs referencing a split Gateway replaced with one parentRef per
+// listener of the given protocols the route attaches to, referencing the shard
+// holding it and, when bySectionName is true, the listener by sectionName.
+// Otherwise, a single parentRef references every shard holding such
+// listeners. The first shard is referenced when the route attaches to none.
+func shardParentRefs(namespace string, routeParentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, protocols []gatewayv1.ProtocolType, shardsByGateway map[types.NamespacedName][]gatewayv1.Gateway, bySectionName bool) []gatewayv1.ParentReference {
+	var parentRefs []gatewayv1.ParentReference
+	for _, parentRef := range routeParentRefs {
+		gatewayKey, ok := parentRefGateway(namespace, parentRef)
+		shards, split := shardsByGateway[gatewayKey]
+		if !ok || !split {
+			parentRefs = append(parentRefs, parentRef)
+			continue
+		}
+
+		var shardRefs []gatewayv1.ParentReference
+		for _, shard := range shards {
+			for _, listener := range shard.Spec.Listeners {
+				if !slices.Contains(protocols, listener.Protocol) || !listenerAccepts(listener, parentRef, hostnames) {
+					continue
+				}
+				shardRef := parentRef
+				shardRef.Name = gatewayv1.ObjectName(shard.Name)
+				if !bySectionName {
+					shardRefs = append(shardRefs, shardRef)
+					break
+				}
+				shardRef.SectionName = ptr.To(listener.Name)
+				shardRefs = append(shardRefs, shardRef)
+			}
+		}
+		if len(shardRefs) == 0 {
+			shardRef := parentRef
+			shardRef.Name = gatewayv1.ObjectName(shards[0].Name)
+			shardRefs = append(shardRefs, shardRef)
+		}
+		parentRefs = append(parentRefs, shardRefs...)
+	}
+	return parentRefs
+}
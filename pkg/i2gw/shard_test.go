@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i2gw
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func Test_ShardGateways(t *testing.T) {
+	gatewayKey := types.NamespacedName{Namespace: "default", Name: "nginx"}
+	ingressRef := ObjectRef{
+		GroupVersionKind: schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+		NamespacedName:   types.NamespacedName{Namespace: "default", Name: "web"},
+	}
+
+	// hostListeners returns the HTTP and HTTPS listeners of the hosts
+	// host-0.com to host-<count-1>.com.
+	hostListeners := func(count int) []gatewayv1.Listener {
+		var listeners []gatewayv1.Listener
+		for i := 0; i < count; i++ {
+			hostname := ptr.To(gatewayv1.Hostname(fmt.Sprintf("host-%d.com", i)))
+			listeners = append(listeners,
+				gatewayv1.Listener{Name: gatewayv1.SectionName(fmt.Sprintf("host-%d-http", i)), Hostname: hostname, Port: 80, Protocol: gatewayv1.HTTPProtocolType},
+				gatewayv1.Listener{Name: gatewayv1.SectionName(fmt.Sprintf("host-%d-https", i)), Hostname: hostname, Port: 443, Protocol: gatewayv1.HTTPSProtocolType},
+			)
+		}
+		return listeners
+	}
+	route := func(name string, sectionName *gatewayv1.SectionName, hostnames ...gatewayv1.Hostname) gatewayv1.HTTPRoute {
+		return gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "nginx", SectionName: sectionName}},
+				},
+				Hostnames: hostnames,
+			},
+		}
+	}
+	gatewayResources := func(listeners []gatewayv1.Listener, routes ...gatewayv1.HTTPRoute) GatewayResources {
+		gatewayResources := GatewayResources{
+			Gateways: map[types.NamespacedName]gatewayv1.Gateway{
+				gatewayKey: {
+					TypeMeta:   metav1.TypeMeta{APIVersion: gatewayv1.GroupVersion.String(), Kind: "Gateway"},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx"},
+					Spec: gatewayv1.GatewaySpec{
+						GatewayClassName: "nginx",
+						Listeners:        listeners,
+						Addresses:        []gatewayv1.GatewayAddress{{Type: ptr.To(gatewayv1.IPAddressType), Value: "192.0.2.1"}},
+					},
+				},
+			},
+			HTTPRoutes: map[types.NamespacedName]gatewayv1.HTTPRoute{},
+		}
+		gatewayResources.AddSources(ptr.To(gatewayResources.Gateways[gatewayKey]), ingressRef)
+		for _, r := range routes {
+			gatewayResources.HTTPRoutes[types.NamespacedName{Namespace: r.Namespace, Name: r.Name}] = r
+		}
+		return gatewayResources
+	}
+
+	testCases := []struct {
+		name               string
+		gatewayResources   GatewayResources
+		maxListeners       int
+		expectedListeners  map[string]int
+		expectedParentRefs map[string][]string
+		expectedAddresses  map[string]int
+		expectingError     bool
+	}{
+		{
+			name:              "gateway within the limit",
+			gatewayResources:  gatewayResources(hostListeners(2), route("host-0", nil, "host-0.com")),
+			maxListeners:      4,
+			expectedListeners: map[string]int{"nginx": 4},
+			expectedParentRefs: map[string][]string{
+				"host-0": {"nginx/"},
+			},
+		},
+		{
+			name:             "listeners of a hostname are kept together",
+			gatewayResources: gatewayResources(hostListeners(5), route("host-0", nil, "host-0.com"), route("host-4", ptr.To[gatewayv1.SectionName]("host-4-https"), "host-4.com")),
+			maxListeners:     5,
+			// Two hosts, i.e. four listeners, per shard.
+			expectedListeners: map[string]int{"nginx-1": 4, "nginx-2": 4, "nginx-3": 2},
+			expectedParentRefs: map[string][]string{
+				"host-0": {"nginx-1/host-0-http", "nginx-1/host-0-https"},
+				"host-4": {"nginx-3/host-4-https"},
+			},
+		},
+		{
+			name:              "route attached to several shards",
+			gatewayResources:  gatewayResources(hostListeners(3), route("catch-all", nil)),
+			maxListeners:      2,
+			expectedListeners: map[string]int{"nginx-1": 2, "nginx-2": 2, "nginx-3": 2},
+			expectedParentRefs: map[string][]string{
+				"catch-all": {
+					"nginx-1/host-0-http", "nginx-1/host-0-https",
+					"nginx-2/host-1-http", "nginx-2/host-1-https",
+					"nginx-3/host-2-http", "nginx-3/host-2-https",
+				},
+			},
+		},
+		{
+			name: "route attached to the hostnameless listener of another shard",
+			gatewayResources: gatewayResources(
+				append([]gatewayv1.Listener{{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType}}, hostListeners(2)...),
+				route("host-1", nil, "host-1.com"),
+			),
+			maxListeners:      2,
+			expectedListeners: map[string]int{"nginx-1": 1, "nginx-2": 2, "nginx-3": 2},
+			expectedParentRefs: map[string][]string{
+				"host-1": {"nginx-1/http", "nginx-3/host-1-http", "nginx-3/host-1-https"},
+			},
+			expectedAddresses: map[string]int{"nginx-1": 1, "nginx-2": 0, "nginx-3": 0},
+		},
+		{
+			name:              "hostnameless route attached to too many listeners references the shards",
+			gatewayResources:  gatewayResources(hostListeners(20), route("catch-all", nil)),
+			maxListeners:      10,
+			expectedListeners: map[string]int{"nginx-1": 10, "nginx-2": 10, "nginx-3": 10, "nginx-4": 10},
+			expectedParentRefs: map[string][]string{
+				"catch-all": {"nginx-1/", "nginx-2/", "nginx-3/", "nginx-4/"},
+			},
+		},
+		{
+			name:             "hostnameless route attached to too many shards",
+			gatewayResources: gatewayResources(hostListeners(33), route("catch-all", nil)),
+			maxListeners:     2,
+			expectingError:   true,
+		},
+		{
+			name: "shard name already taken",
+			gatewayResources: func() GatewayResources {
+				gatewayResources := gatewayResources(hostListeners(2))
+				gatewayResources.Gateways[types.NamespacedName{Namespace: "default", Name: "nginx-1"}] = gatewayv1.Gateway{}
+				return gatewayResources
+			}(),
+			maxListeners:   2,
+			expectingError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ShardGateways(&tc.gatewayResources, tc.maxListeners)
+			if tc.expectingError {
+				if len(errs) == 0 {
+					t.Fatalf("Expected errors, got none")
+				}
+				return
+			}
+			if len(errs) > 0 {
+				t.Fatalf("Expected no errors, got %v", errs)
+			}
+
+			listeners := map[string]int{}
+			addresses := map[string]int{}
+			for _, g := range tc.gatewayResources.Gateways {
+				listeners[g.Name] = len(g.Spec.Listeners)
+				addresses[g.Name] = len(g.Spec.Addresses)
+				if sources := tc.gatewayResources.Sources[ObjectRefFor(&g)]; len(sources) != 1 || sources[0] != ingressRef {
+					t.Errorf("Expected Gateway %s to be converted from %s, got %v", g.Name, ingressRef, sources)
+				}
+			}
+			if diff := cmp.Diff(tc.expectedListeners, listeners); diff != "" {
+				t.Errorf("Unexpected listeners by Gateway, diff (-want +got):\n%s", diff)
+			}
+			if tc.expectedAddresses != nil {
+				if diff := cmp.Diff(tc.expectedAddresses, addresses); diff != "" {
+					t.Errorf("Unexpected addresses by Gateway, diff (-want +got):\n%s", diff)
+				}
+			}
+
+			parentRefs := map[string][]string{}
+			for _, r := range tc.gatewayResources.HTTPRoutes {
+				for _, parentRef := range r.Spec.ParentRefs {
+					parentRefs[r.Name] = append(parentRefs[r.Name], fmt.Sprintf("%s/%s", parentRef.Name, ptr.Deref(parentRef.SectionName, "")))
+				}
+			}
+			if diff := cmp.Diff(tc.expectedParentRefs, parentRefs); diff != "" {
+				t.Errorf("Unexpected parentRefs by HTTPRoute, diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}